@@ -17,7 +17,7 @@ limitations under the License.
 package base
 
 import (
-	"errors"
+	"database/sql"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -38,9 +38,14 @@ func (tdb *TodoDb) FetchProjectId(folder, branch string) int {
 		return id
 	}
 
+	name, ticket := branch, ""
+	if branch != "*" {
+		name, ticket = tdb.nameRules.Apply(branch)
+	}
+
 	row = tdb.db.QueryRow(
-		`insert into project (folder, branch, name) values ($1, $2, $3) returning project_id`,
-		folder, branch, branch,
+		`insert into project (folder, branch, name, ticket) values ($1, $2, $3, $4) returning project_id`,
+		folder, branch, name, ticket,
 	)
 
 	row.Scan(&id)
@@ -59,11 +64,14 @@ func (tdb *TodoDb) TodoCount(projId int) int {
 	return 0
 }
 
-func (tdb *TodoDb) AddTodo(projId int, task string) (int, int) {
+// AddTodo adds an item at the end of the project. The first line of the
+// text is the title of the item, and the rest are the notes.
+func (tdb *TodoDb) AddTodo(projId int, text string) (int, int) {
 	count := tdb.TodoCount(projId)
+	task, notes := SplitTask(text)
 	row := tdb.db.QueryRow(
-		`insert into todo (project_id, task, position) values ($1, $2, $3) returning todo_id`,
-		projId, task, count+1,
+		`insert into todo (project_id, task, notes, position) values ($1, $2, $3, $4) returning todo_id`,
+		projId, task, notes, count+1,
 	)
 
 	var id int
@@ -72,28 +80,22 @@ func (tdb *TodoDb) AddTodo(projId int, task string) (int, int) {
 }
 
 func (tdb *TodoDb) AddTodos(projId int, tasks []string) error {
-	if len(tasks) == 0 {
-		return errors.New("No items to add.")
-	}
-	count := tdb.TodoCount(projId)
-	insert := make([]map[string]any, len(tasks))
+	items := make([]TodoInput, len(tasks))
 	for i, t := range tasks {
-		insert[i] = map[string]any{"project_id": projId, "task": t, "position": count + i + 1}
+		items[i] = TodoInput{Task: t}
 	}
-	_, err := tdb.db.NamedExec(`insert into todo(project_id, task, position)
-        values (:project_id, :task, :position)`, insert)
-	return err
+	return tdb.AddTodoTree(projId, 0, items)
 }
 
 func (tdb *TodoDb) GetProject(projId int) Project {
 	var proj Project
-	tdb.db.Get(&proj, "select project_id, folder, branch, name from project where project_id = $1", projId)
+	tdb.db.Get(&proj, "select project_id, folder, branch, name, ticket from project where project_id = $1", projId)
 	return proj
 }
 
 func (tdb *TodoDb) TodoItems(projId int, f func(t Todo)) error {
 	todo := Todo{}
-	rows, err := tdb.db.Queryx(`select todo_id, project_id, task, position, created_at, done_at, committed_at 
+	rows, err := tdb.db.Queryx(`select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
 		from todo where project_id = $1 order by position`, projId)
 
 	if err != nil {
@@ -113,7 +115,7 @@ func (tdb *TodoDb) TodoItems(projId int, f func(t Todo)) error {
 
 func (tdb *TodoDb) TodoItemsDone(projId int, f func(t Todo)) error {
 	todo := Todo{}
-	rows, err := tdb.db.Queryx(`select todo_id, project_id, task, position, created_at, done_at, committed_at 
+	rows, err := tdb.db.Queryx(`select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
 		from todo where project_id = $1 and done_at is not null order by done_at`, projId)
 
 	if err != nil {
@@ -133,7 +135,7 @@ func (tdb *TodoDb) TodoItemsDone(projId int, f func(t Todo)) error {
 
 func (tdb *TodoDb) TodoItemsForCommit(projId int, previous bool, f func(t Todo)) error {
 	todo := Todo{}
-	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at 
+	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
 		from todo where project_id = :projId and done_at is not null`
 
 	if previous {
@@ -165,8 +167,10 @@ func (tdb *TodoDb) TodoItemsForCommit(projId int, previous bool, f func(t Todo))
 }
 
 func (tdb *TodoDb) TodoWhat(projId int) *Todo {
-	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at 
-		from todo where project_id = $1 and done_at is null order by position limit 1`
+	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
+		from todo where project_id = $1 and done_at is null
+		and not exists (select 1 from todo c where c.parent_id = todo.todo_id and c.done_at is null)
+		order by position limit 1`
 	row := tdb.db.QueryRowx(sql, projId)
 	todo := Todo{}
 	err := row.StructScan(&todo)
@@ -178,26 +182,133 @@ func (tdb *TodoDb) TodoWhat(projId int) *Todo {
 	return &todo
 }
 
+// GetTodo returns the item with the given id, or nil if not found
+func (tdb *TodoDb) GetTodo(todoId int) *Todo {
+	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
+		from todo where todo_id = $1`
+	row := tdb.db.QueryRowx(sql, todoId)
+	todo := Todo{}
+	err := row.StructScan(&todo)
+
+	if err != nil {
+		return nil
+	}
+
+	return &todo
+}
+
+// TodoAtPosition returns the item at the given position in the project,
+// or nil if not found
+func (tdb *TodoDb) TodoAtPosition(projId, position int) *Todo {
+	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
+		from todo where project_id = $1 and position = $2`
+	row := tdb.db.QueryRowx(sql, projId, position)
+	todo := Todo{}
+	err := row.StructScan(&todo)
+
+	if err != nil {
+		return nil
+	}
+
+	return &todo
+}
+
+// TodoLastDone returns the most recently completed item in the project,
+// or nil if not found
+func (tdb *TodoDb) TodoLastDone(projId int) *Todo {
+	sql := `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
+		from todo where project_id = $1 and done_at is not null 
+		order by done_at desc, todo_id desc limit 1`
+	row := tdb.db.QueryRowx(sql, projId)
+	todo := Todo{}
+	err := row.StructScan(&todo)
+
+	if err != nil {
+		return nil
+	}
+
+	return &todo
+}
+
+// ChangePosition moves an item from one position to another. Sub-items
+// are moved together with their parent, and they are kept under it.
 func (tdb *TodoDb) ChangePosition(todoId, from, to int) error {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var projId int
+	if err = tx.Get(&projId, "select project_id from todo where todo_id = $1", todoId); err != nil {
+		return err
+	}
+
 	sql := `update todo set position = case 
 		when todo_id = :todoId then :to
 		when position >= :to and position < :from then position + 1
 		when position > :from and position <= :to then position - 1
 		else position
-	end where project_id=(select project_id from todo where todo_id=:todoId)`
+	end where project_id=:projId`
 
-	_, err := tdb.db.NamedExec(sql, map[string]any{
+	_, err = tx.NamedExec(sql, map[string]any{
 		"todoId": todoId,
+		"projId": projId,
 		"from":   from,
 		"to":     to,
 	})
-	return err
+
+	if err != nil {
+		return err
+	}
+
+	if err = normalizePositions(tx, projId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// MoveTodo moves an item to another project and updates positions
+// ReorderTodos orders the items of the project as given, where sub-items
+// are kept under their parents and are ordered only among their siblings.
+// Items of the project that are not given are placed after the given ones.
+func (tdb *TodoDb) ReorderTodos(projId int, ids []int) error {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("update todo set position = position + $1 where project_id = $2", len(ids), projId)
+	if err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		_, err = tx.Exec("update todo set position = $1 where todo_id = $2 and project_id = $3", i+1, id, projId)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = normalizePositions(tx, projId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MoveTodo moves an item together with its sub-items to the end of
+// another project and updates positions in both projects
 func (tdb *TodoDb) MoveTodo(todoId, projId int) error {
-	count := tdb.TodoCount(projId)
+	return tdb.MoveTodos([]int{todoId}, projId)
+}
 
+// MoveTodos moves the items together with their sub-items to the end of
+// another project in a single transaction, keeping their order
+func (tdb *TodoDb) MoveTodos(ids []int, projId int) error {
 	tx, err := tdb.db.Beginx()
 
 	if err != nil {
@@ -205,56 +316,290 @@ func (tdb *TodoDb) MoveTodo(todoId, projId int) error {
 	}
 	defer tx.Rollback()
 
-	// update positions first
-	_, err = tx.Exec(`with my_item as (
-		select project_id, position from todo
-		where todo_id=$1
+	for _, id := range ids {
+		if err = moveTodo(tx, id, projId); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// moveTodo moves the item within the transaction, unless it's already
+// in the project, i.e. because it was moved together with its parent
+func moveTodo(tx *sqlx.Tx, todoId, projId int) error {
+	var fromProjId int
+	if err := tx.Get(&fromProjId, "select project_id from todo where todo_id = $1", todoId); err != nil {
+		return err
+	}
+	if fromProjId == projId {
+		return nil
+	}
+
+	var count int
+	if err := tx.Get(&count, "select count(*) from todo where project_id = $1", projId); err != nil {
+		return err
+	}
+
+	// move, positions are placed after the existing items and fixed below
+	_, err := tx.Exec(`with recursive subtree(todo_id) as (
+		select $1
+		union
+		select t.todo_id from todo t join subtree s on t.parent_id = s.todo_id
 	)
-	update todo set position=position-1
-	where project_id = (select project_id from my_item limit 1)
-	and position > (select position from my_item limit 1)`, todoId)
+	update todo set project_id=$2, position=position+$3, 
+	parent_id=case when todo_id=$1 then null else parent_id end,
+	created_at=datetime(current_timestamp, 'localtime') 
+	where todo_id in (select todo_id from subtree)`, todoId, projId, count)
 
 	if err != nil {
 		return err
 	}
 
-	// move
-	_, err = tx.Exec(`update todo set project_id=$1, position=$2, 
-	created_at=datetime(current_timestamp, 'localtime') where todo_id=$3`, projId, count+1, todoId)
+	if err = normalizePositions(tx, fromProjId); err != nil {
+		return err
+	}
+
+	return normalizePositions(tx, projId)
+}
+
+// CopyTodos copies the items together with their sub-items to the end of
+// another project in a single transaction, keeping their order. Copies are
+// not completed, and sub-items copied with their parents are skipped.
+// Returns the ids of the copies of the items that weren't skipped.
+func (tdb *TodoDb) CopyTodos(ids []int, projId int) ([]int, error) {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	copied := map[int]bool{}
+	newIds := []int{}
+	for _, id := range ids {
+		if copied[id] {
+			continue
+		}
+		newId, err := copyTodo(tx, id, projId, copied)
+		if err != nil {
+			return nil, err
+		}
+		newIds = append(newIds, newId)
+	}
+
+	if err = normalizePositions(tx, projId); err != nil {
+		return nil, err
+	}
+
+	return newIds, tx.Commit()
+}
+
+// copyTodo copies the item with its sub-items within the transaction,
+// records the ids of the copied items and returns the id of the copy
+func copyTodo(tx *sqlx.Tx, todoId, projId int, copied map[int]bool) (int, error) {
+	var items []Todo
+	err := tx.Select(&items, `with recursive subtree(todo_id) as (
+		select $1
+		union
+		select t.todo_id from todo t join subtree s on t.parent_id = s.todo_id
+	)
+	select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text
+	from todo where todo_id in (select todo_id from subtree) order by position`, todoId)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := tx.Get(&count, "select count(*) from todo where project_id = $1", projId); err != nil {
+		return 0, err
+	}
+
+	copies := make(map[int64]int64, len(items))
+
+	for i, t := range items {
+		parent := sql.NullInt64{}
+		if id, ok := copies[t.ParentId.Int64]; ok && t.Id != todoId {
+			parent = sql.NullInt64{Int64: id, Valid: true}
+		}
+
+		var id int64
+		err = tx.Get(&id, `insert into todo (project_id, task, notes, position, parent_id, due_at, estimate, source, source_text) 
+		values (?, ?, ?, ?, ?, ?, ?, ?, ?) returning todo_id`,
+			projId, t.Task, t.Notes, count+i+1, parent, t.DueAt, t.Estimate, t.Source, t.SourceText)
+
+		if err != nil {
+			return 0, err
+		}
+
+		copies[int64(t.Id)] = id
+		copied[t.Id] = true
+	}
+
+	return int(copies[int64(todoId)]), nil
+}
+
+// TodoDone marks the item as done or not done. Completing the last pending
+// sub-item completes the parent as well, and reopening a sub-item reopens
+// all of its parents.
+func (tdb *TodoDb) TodoDone(todoId int, done bool) error {
+	return tdb.TodosDone([]int{todoId}, done)
+}
+
+// TodosDone marks the items as done or not done in a single transaction,
+// see TodoDone
+func (tdb *TodoDb) TodosDone(ids []int, done bool) error {
+	tx, err := tdb.db.Beginx()
 
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if err = todoDone(tx, id, done); err != nil {
+			return err
+		}
+	}
 
 	return tx.Commit()
 }
 
-func (tdb *TodoDb) TodoDone(todoId int, done bool) error {
-	var sql string
+// todoDone marks the item as done or not done within the transaction
+func todoDone(tx *sqlx.Tx, todoId int, done bool) error {
+	var err error
+
 	if done {
-		sql = "update todo set done_at=datetime(current_timestamp, 'localtime') where todo_id=$1"
+		_, err = tx.Exec("update todo set done_at=datetime(current_timestamp, 'localtime') where todo_id=$1", todoId)
+		if err != nil {
+			return err
+		}
+
+		// walk up while all of the siblings are done
+		for id := todoId; ; {
+			var parentId sql.NullInt64
+			if err = tx.Get(&parentId, "select parent_id from todo where todo_id=$1", id); err != nil {
+				return err
+			}
+			if !parentId.Valid {
+				break
+			}
+
+			res, err := tx.Exec(`update todo set done_at=datetime(current_timestamp, 'localtime')
+			where todo_id=$1 and done_at is null
+			and not exists (select 1 from todo where parent_id=$1 and done_at is null)`, parentId.Int64)
+			if err != nil {
+				return err
+			}
+			if n, _ := res.RowsAffected(); n == 0 {
+				break
+			}
+			id = int(parentId.Int64)
+		}
 	} else {
-		sql = "update todo set done_at=null where todo_id=$1"
+		_, err = tx.Exec(`with recursive ancestors(todo_id) as (
+			select $1
+			union
+			select t.parent_id from todo t join ancestors a on t.todo_id = a.todo_id
+			where t.parent_id is not null
+		)
+		update todo set done_at=null where todo_id in (select todo_id from ancestors)`, todoId)
+		if err != nil {
+			return err
+		}
 	}
-	_, err := tdb.db.Exec(sql, todoId)
-	return err
+
+	return nil
 }
 
+// Delete deletes an item and updates positions of the items below it.
+// Sub-items of the deleted item are moved up to its parent.
 func (tdb *TodoDb) Delete(todoId int) error {
-	_, err := tdb.db.Exec("delete from todo where todo_id=$1", todoId)
-	return err
+	return tdb.DeleteTodos([]int{todoId})
+}
+
+// DeleteTodos deletes the items in a single transaction, see Delete
+func (tdb *TodoDb) DeleteTodos(ids []int) error {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if err = deleteTodo(tx, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
+// deleteTodo deletes the item within the transaction
+func deleteTodo(tx *sqlx.Tx, todoId int) error {
+	var projId int
+	if err := tx.Get(&projId, "select project_id from todo where todo_id = $1", todoId); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`update todo set parent_id = (select parent_id from todo where todo_id=$1)
+	where parent_id=$1`, todoId)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("delete from todo where todo_id=$1", todoId)
+
+	if err != nil {
+		return err
+	}
+
+	return normalizePositions(tx, projId)
+}
+
+// UpdateTask updates the title of the item, leaving the notes as they are
 func (tdb *TodoDb) UpdateTask(todoId int, task string) error {
 	_, err := tdb.db.Exec("update todo set task=$1 where todo_id=$2", task, todoId)
 	return err
 }
 
+// SetDue sets the due date of the item in YYYY-MM-DD format,
+// or removes it if the date is empty
+func (tdb *TodoDb) SetDue(todoId int, due string) error {
+	_, err := tdb.db.Exec("update todo set due_at=nullif($1, '') where todo_id=$2", due, todoId)
+	return err
+}
+
+// SetSource sets the location of the source code comment that the item was
+// made from, as a file:line reference, along with the text of the comment
+func (tdb *TodoDb) SetSource(todoId int, source, text string) error {
+	_, err := tdb.db.Exec("update todo set source=nullif($1, ''), source_text=nullif($2, '') where todo_id=$3",
+		source, text, todoId)
+	return err
+}
+
+// UpdateItemText updates both the title and the notes of the item
+// from the text, where the first line is the title
+func (tdb *TodoDb) UpdateItemText(todoId int, text string) error {
+	task, notes := SplitTask(text)
+	_, err := tdb.db.Exec("update todo set task=$1, notes=$2 where todo_id=$3", task, notes, todoId)
+	return err
+}
+
 func (tdb *TodoDb) UpdateProjectName(projId int, name string) error {
 	_, err := tdb.db.Exec("update project set name=$1 where project_id=$2", name, projId)
 	return err
 }
 
+// UpdateProjectTicket sets the ticket id of the project
+func (tdb *TodoDb) UpdateProjectTicket(projId int, ticket string) error {
+	_, err := tdb.db.Exec("update project set ticket=$1 where project_id=$2", ticket, projId)
+	return err
+}
+
 func (tdb *TodoDb) SetItemsCommitted(projId int, previous bool) error {
 	sql := `update todo set committed_at=:ts where project_id=:projId and done_at is not null`
 
@@ -275,7 +620,7 @@ func (tdb *TodoDb) SetItemsCommitted(projId int, previous bool) error {
 func (tdb *TodoDb) GetItemsAndBranch(ids []int) ([]*ItemAndBranch, error) {
 	var resultSet []*ItemAndBranch
 	q, args, err := sqlx.In(
-		`select t.task as item_name, p.branch as branch_name from todo t natural join project p 
+		`select t.todo_id, t.task as item_name, p.branch as branch_name from todo t natural join project p 
 		where t.todo_id in (?) order by p.project_id desc, t.created_at desc`, ids)
 
 	if err != nil {
@@ -293,7 +638,8 @@ func (tdb *TodoDb) GetItemsAndBranch(ids []int) ([]*ItemAndBranch, error) {
 
 func (tdb *TodoDb) GetBranches(repo string) ([]BranchItem, error) {
 	var resultSet []BranchItem
-	sql := `select p.branch as branch_name, count(*) as item_count, p.project_id
+	sql := `select p.branch as branch_name, count(*) as item_count, p.project_id,
+	sum(t.done_at is null) as pending_count, sum(t.done_at is not null) as done_count
 	from project p
 	natural join todo t
 	where p.folder = ? and p.branch != '*'
@@ -306,6 +652,21 @@ func (tdb *TodoDb) GetBranches(repo string) ([]BranchItem, error) {
 	return resultSet, nil
 }
 
+// GetRepos returns the folders of the repositories that have items
+func (tdb *TodoDb) GetRepos() ([]string, error) {
+	var repos []string
+	sql := `select distinct p.folder from project p
+	natural join todo t
+	where p.branch != '*'
+	order by p.folder`
+
+	if err := tdb.db.Select(&repos, sql); err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
 func (tdb *TodoDb) DeleteProject(projId int) error {
 	_, err := tdb.db.Exec("delete from project where project_id = ?", projId)
 	return err
@@ -316,10 +677,43 @@ func (tdb *TodoDb) Vacuum() error {
 	return err
 }
 
+// CopyProjectItems copies the items of one project to another,
+// keeping the sub-items under their copied parents
 func (tdb *TodoDb) CopyProjectItems(projFrom, projTo int) error {
-	sql := `insert into todo (project_id, task, position) 
-	select ?, task, position from todo where project_id = ?`
+	var items []Todo
+	err := tdb.db.Select(&items, `select todo_id, project_id, task, position, created_at, done_at, committed_at, parent_id, notes, due_at, estimate, source, source_text 
+	from todo where project_id = ? order by position`, projFrom)
 
-	_, err := tdb.db.Exec(sql, projTo, projFrom)
-	return err
+	if err != nil {
+		return err
+	}
+
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	copies := make(map[int64]int64, len(items))
+
+	for _, t := range items {
+		parent := sql.NullInt64{}
+		if id, ok := copies[t.ParentId.Int64]; ok && t.ParentId.Valid {
+			parent = sql.NullInt64{Int64: id, Valid: true}
+		}
+
+		var id int64
+		err = tx.Get(&id, `insert into todo (project_id, task, notes, position, parent_id, due_at, estimate, source, source_text) 
+		values (?, ?, ?, ?, ?, ?, ?, ?, ?) returning todo_id`,
+			projTo, t.Task, t.Notes, t.Position, parent, t.DueAt, t.Estimate, t.Source, t.SourceText)
+
+		if err != nil {
+			return err
+		}
+
+		copies[int64(t.Id)] = id
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,273 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// reasons for making a backup, used as a part of the file name
+const (
+	BackupReasonManual    = "manual"
+	BackupReasonMigration = "migration"
+	BackupReasonDelete    = "delete"
+	BackupReasonFix       = "fix"
+	BackupReasonRestore   = "restore"
+)
+
+const (
+	backupPrefix     = "gitodo_"
+	backupExt        = ".db"
+	backupTimeFormat = "20060102-150405.000"
+	defaultKeep      = 10
+)
+
+type BackupFile struct {
+	Path      string
+	Reason    string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// BackupDir returns the directory where backups are stored. It is read from
+// the GITODO_BACKUP_DIR environment variable, and defaults to the
+// ".gitodo_backups" directory next to the database file.
+func BackupDir() (string, error) {
+	if p := os.Getenv("GITODO_BACKUP_DIR"); p != "" {
+		return filepath.Abs(p)
+	}
+
+	dbfile, err := DbFile()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(dbfile), ".gitodo_backups"), nil
+}
+
+// BackupKeep returns the number of backups to keep, read from the
+// GITODO_BACKUP_KEEP environment variable. Zero disables automatic backups.
+func BackupKeep() int {
+	keep, err := strconv.Atoi(os.Getenv("GITODO_BACKUP_KEEP"))
+	if err != nil || keep < 0 {
+		return defaultKeep
+	}
+	return keep
+}
+
+// Backup writes a copy of the database into the backup directory and
+// removes the oldest backups over the retention limit
+func (tdb *TodoDb) Backup(reason string) (string, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	name := backupPrefix + time.Now().Format(backupTimeFormat) + "_" + reason + backupExt
+	path := filepath.Join(dir, name)
+
+	if _, err = tdb.db.Exec("vacuum into ?", path); err != nil {
+		return "", err
+	}
+
+	if keep := BackupKeep(); keep > 0 {
+		if err = rotateBackups(dir, keep); err != nil {
+			return path, err
+		}
+	}
+
+	return path, nil
+}
+
+// AutoBackup makes a backup before a destructive operation unless
+// automatic backups are turned off, or the database is not a file
+func (tdb *TodoDb) AutoBackup(reason string) (string, error) {
+	if tdb.file == "" || BackupKeep() == 0 {
+		return "", nil
+	}
+
+	return tdb.Backup(reason)
+}
+
+// ListBackups lists backup files in the backup directory, newest first
+func ListBackups() ([]BackupFile, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return listBackups(dir)
+}
+
+func listBackups(dir string) ([]BackupFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []BackupFile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := []BackupFile{}
+	for _, e := range entries {
+		bf, ok := parseBackupName(e.Name())
+		if e.IsDir() || !ok {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			bf.Size = info.Size()
+		}
+		bf.Path = filepath.Join(dir, e.Name())
+		files = append(files, bf)
+	}
+
+	slices.SortFunc(files, func(a, b BackupFile) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return files, nil
+}
+
+// parseBackupName reads the time and the reason from the file name
+func parseBackupName(name string) (BackupFile, bool) {
+	if !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupExt) {
+		return BackupFile{}, false
+	}
+
+	ts, reason, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupExt), "_")
+	t, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+	if err != nil {
+		return BackupFile{}, false
+	}
+
+	return BackupFile{Reason: reason, CreatedAt: t}, true
+}
+
+func rotateBackups(dir string, keep int) error {
+	files, err := listBackups(dir)
+	if err != nil {
+		return err
+	}
+
+	for i := keep; i < len(files); i++ {
+		if err = os.Remove(files[i].Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore replaces the database with the given backup file. The current
+// database is backed up first, and the restored one is migrated if needed.
+// The backup is copied before that, since the rotation of backups might
+// delete it.
+func (tdb *TodoDb) Restore(file string) error {
+	if tdb.file == "" {
+		return errors.New("Restore is supported only for databases stored in a file.")
+	}
+
+	if err := checkBackup(file); err != nil {
+		return fmt.Errorf("%s is not a valid backup: %w", file, err)
+	}
+
+	tmp := tdb.file + ".restore"
+	if err := copyFile(file, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	defer os.Remove(tmp)
+
+	if _, err := tdb.Backup(BackupReasonRestore); err != nil {
+		return err
+	}
+
+	if err := tdb.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, tdb.file); err != nil {
+		return err
+	}
+
+	// the current database was backed up already
+	restored, err := openTodoDb(tdb.file, false)
+	if err != nil {
+		return err
+	}
+
+	tdb.db = restored.db
+	return nil
+}
+
+// checkBackup verifies that the file is an intact gitodo database
+func checkBackup(file string) error {
+	if _, err := os.Stat(file); err != nil {
+		return err
+	}
+
+	db, err := sqlx.Connect("sqlite3", "file:"+file+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result string
+	if err = db.Get(&result, "pragma integrity_check"); err != nil {
+		return err
+	}
+	if result != "ok" {
+		return errors.New(result)
+	}
+
+	var count int
+	return db.Get(&count, "select count(*) from project")
+}
+
+func copyFile(from, to string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestBackupRotation verifies that backups are valid
+// and that only the latest ones are kept
+func TestBackupRotation(t *testing.T) {
+	t.Setenv("GITODO_BACKUP_DIR", t.TempDir())
+	t.Setenv("GITODO_BACKUP_KEEP", "2")
+
+	db, err := NewTodoDbSrc("file:backup.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	var latest string
+	for _, reason := range []string{BackupReasonManual, BackupReasonDelete, BackupReasonFix} {
+		latest, err = db.Backup(reason)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := ListBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(files))
+	}
+
+	if files[0].Path != latest || files[0].Reason != BackupReasonFix {
+		t.Errorf("expected latest backup first, got %v", files[0])
+	}
+
+	if err = checkBackup(latest); err != nil {
+		t.Errorf("backup not valid: %v", err)
+	}
+}
+
+// TestRestoreOnlyBackup verifies that the only backup can be restored
+// when the backup made before restoring rotates it away
+func TestRestoreOnlyBackup(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GITODO_DB", filepath.Join(dir, "todo.db"))
+	t.Setenv("GITODO_BACKUP_DIR", filepath.Join(dir, "backups"))
+	t.Setenv("GITODO_BACKUP_KEEP", "1")
+
+	db, err := NewTodoDb()
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	// a new database is not backed up
+	files, err := ListBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected no backups of a new database, got %d", len(files))
+	}
+
+	projId := db.FetchProjectId("/tmp/restore", "main")
+	db.AddTodo(projId, "first")
+
+	backup, err := db.Backup(BackupReasonManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.AddTodo(projId, "second")
+
+	if err = db.Restore(backup); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	if count := db.TodoCount(projId); count != 1 {
+		t.Errorf("expected 1 item after restore, got %d", count)
+	}
+
+	files, err = ListBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Reason != BackupReasonRestore {
+		t.Errorf("expected only the backup made before restoring, got %v", files)
+	}
+}
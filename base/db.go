@@ -24,49 +24,99 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+const dbParams = "?_fk=true&cache=shared&_loc=auto"
+
 type TodoDb struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	file      string
+	nameRules NameRules
 }
 
-func NewTodoDb() (*TodoDb, error) {
-	var dbfile string
-	params := "?_fk=true&cache=shared&_loc=auto"
+// DbFile returns the path to the database file, either from the GITODO_DB
+// environment variable, or the default one in the user's home directory
+func DbFile() (string, error) {
 	if p := os.Getenv("GITODO_DB"); p != "" {
 		path, err := filepath.Abs(p)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		dbfile = "file:" + path + params
-	} else {
-		homedir, err := os.UserHomeDir()
-		if err != nil {
-			homedir = "."
+		return path, nil
+	}
+
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		homedir = "."
+	}
+	return homedir + "/.gitodo.db", nil
+}
+
+func NewTodoDb() (*TodoDb, error) {
+	dbfile, err := DbFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// a new database has no data to back up
+	_, err = os.Stat(dbfile)
+	return openTodoDb(dbfile, err == nil)
+}
+
+// openTodoDb opens and migrates the database file, and backs it up first
+// if the backup is requested and there are pending migrations
+func openTodoDb(dbfile string, backup bool) (*TodoDb, error) {
+	tdb, err := connect("file:" + dbfile + dbParams)
+	if err != nil {
+		return nil, err
+	}
+
+	tdb.file = dbfile
+
+	// back up the existing data before changing the structure
+	if pending, err := migrations.Pending(tdb.db.DB); backup && err == nil && len(pending) > 0 {
+		if _, err := tdb.AutoBackup(BackupReasonMigration); err != nil {
+			tdb.Close()
+			return nil, err
 		}
-		dbfile = "file:" + homedir + "/.gitodo.db" + params
 	}
 
-	return NewTodoDbSrc(dbfile)
+	if err = migrations.Migrate(tdb.db.DB); err != nil {
+		tdb.Close()
+		return nil, err
+	}
+
+	return tdb, nil
 }
 
 func NewTodoDbSrc(path string) (*TodoDb, error) {
-	db, err := sqlx.Connect("sqlite3", path)
+	tdb, err := connect(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	db.SetMaxOpenConns(1)
+	err = migrations.Migrate(tdb.db.DB)
 
-	err = migrations.Migrate(db.DB)
+	if err != nil {
+		tdb.Close()
+		return nil, err
+	}
+
+	return tdb, nil
+}
+
+func connect(path string) (*TodoDb, error) {
+	db, err := sqlx.Connect("sqlite3", path)
 
 	if err != nil {
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(1)
+
 	return &TodoDb{db: db}, nil
 }
 
@@ -0,0 +1,170 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// EstimateSummary holds the estimates of a project, in seconds. The project
+// can have its own estimate, and the items can have theirs.
+type EstimateSummary struct {
+	ProjectSec   int `json:"project_sec"`
+	ItemsSec     int `json:"items_sec"`
+	RemainingSec int `json:"remaining_sec"`
+	ElapsedSec   int `json:"elapsed_sec"`
+}
+
+// TotalSec returns the estimate of the project if set,
+// or the sum of the item estimates otherwise
+func (es *EstimateSummary) TotalSec() int {
+	if es.ProjectSec > 0 {
+		return es.ProjectSec
+	}
+	return es.ItemsSec
+}
+
+// SetEstimate sets the estimate of the item in seconds, or clears it if zero
+func (tdb *TodoDb) SetEstimate(todoId, seconds int) error {
+	_, err := tdb.db.Exec("update todo set estimate=nullif($1, 0) where todo_id=$2", seconds, todoId)
+	return err
+}
+
+// SetProjectEstimate sets the estimate of the project in seconds, or clears it if zero
+func (tdb *TodoDb) SetProjectEstimate(projId, seconds int) error {
+	_, err := tdb.db.Exec("update project set total_estimate=nullif($1, 0) where project_id=$2", seconds, projId)
+	return err
+}
+
+// GetEstimateSummary sums up the estimates of the project and its items
+// along with the tracked time. The remaining estimate is the sum of the
+// estimates of the pending items, or the estimate of the project minus
+// the tracked time if none of the items are estimated.
+func (tdb *TodoDb) GetEstimateSummary(projId int) (*EstimateSummary, error) {
+	es := &EstimateSummary{}
+	err := tdb.db.QueryRowx(`select 
+		coalesce((select total_estimate from project where project_id = $1), 0),
+		coalesce((select sum(estimate) from todo where project_id = $1), 0),
+		coalesce((select sum(estimate) from todo where project_id = $1 and done_at is null), 0)`, projId).
+		Scan(&es.ProjectSec, &es.ItemsSec, &es.RemainingSec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if es.ElapsedSec, err = tdb.GetProjectTime(projId); err != nil {
+		return nil, err
+	}
+
+	if es.ItemsSec == 0 {
+		es.RemainingSec = max(es.ProjectSec-es.ElapsedSec, 0)
+	}
+
+	return es, nil
+}
+
+// ItemTimes attributes the time tracked on the project to its completed
+// items. An item gets the time tracked since it was created or since the
+// previous item was completed, whichever is later, until it was completed.
+// The result maps item ids to seconds.
+func (tdb *TodoDb) ItemTimes(projId int) (map[int]int, error) {
+	type span struct{ from, to time.Time }
+	parse := func(s string) time.Time {
+		t, _ := time.ParseInLocation(time.DateTime, s, time.Local)
+		return t
+	}
+
+	entries := []TimeEntry{}
+	err := tdb.db.Select(&entries, `select timesheet_id, project_id, action, created_at from timesheet 
+	where project_id = $1 order by created_at, timesheet_id`, projId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []span{}
+	for i, e := range entries {
+		if e.Action != TimesheetActionStart {
+			continue
+		}
+		s := span{from: parse(e.CreatedAt), to: time.Now()}
+		if i+1 < len(entries) && entries[i+1].Action == TimesheetActionStop {
+			s.to = parse(entries[i+1].CreatedAt)
+		}
+		sessions = append(sessions, s)
+	}
+
+	items := []struct {
+		Id        int            `db:"todo_id"`
+		CreatedAt string         `db:"created_at"`
+		DoneAt    sql.NullString `db:"done_at"`
+	}{}
+	err = tdb.db.Select(&items, `select todo_id, created_at, done_at from todo 
+	where project_id = $1 and done_at is not null order by done_at, position`, projId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[int]int, len(items))
+	prev := time.Time{}
+
+	for _, item := range items {
+		from, to := parse(item.CreatedAt), parse(item.DoneAt.String)
+		if prev.After(from) {
+			from = prev
+		}
+
+		total := time.Duration(0)
+		for _, s := range sessions {
+			start, end := s.from, s.to
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+
+		times[item.Id] = int(total.Seconds())
+		prev = to
+	}
+
+	return times, nil
+}
+
+// FormatEstimate formats the seconds in hours and minutes, i.e. 1h30m
+func FormatEstimate(s int) string {
+	h, m := s/3600, (s%3600+30)/60
+	if m == 60 {
+		h, m = h+1, 0
+	}
+
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestEstimateSummary(t *testing.T) {
+	db, err := NewTodoDbSrc("file:estimate.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	projId := db.FetchProjectId("/tmp/repo", "main")
+	db.AddTodos(projId, []string{"one", "two", "three"})
+
+	es, err := db.GetEstimateSummary(projId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es.TotalSec() != 0 {
+		t.Errorf("expected no estimate, got %v", es)
+	}
+
+	db.SetProjectEstimate(projId, 7200)
+	es, _ = db.GetEstimateSummary(projId)
+	if es.TotalSec() != 7200 || es.RemainingSec != 7200 {
+		t.Errorf("expected the project estimate to be remaining, got %v", es)
+	}
+
+	one, two := db.TodoAtPosition(projId, 1), db.TodoAtPosition(projId, 2)
+	db.SetEstimate(one.Id, 1800)
+	db.SetEstimate(two.Id, 3600)
+	db.TodoDone(one.Id, true)
+
+	es, _ = db.GetEstimateSummary(projId)
+	expected := EstimateSummary{ProjectSec: 7200, ItemsSec: 5400, RemainingSec: 3600}
+	if *es != expected {
+		t.Errorf("expected %v, got %v", expected, es)
+	}
+
+	db.SetProjectEstimate(projId, 0)
+	es, _ = db.GetEstimateSummary(projId)
+	if es.TotalSec() != 5400 {
+		t.Errorf("expected the sum of the items, got %d", es.TotalSec())
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := map[int]string{0: "0m", 45 * 60: "45m", 3600: "1h", 5400: "1h30m", 3599: "1h", 36000: "10h"}
+
+	for s, expected := range tests {
+		if got := FormatEstimate(s); got != expected {
+			t.Errorf("%d: expected %q, got %q", s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// TodoFilter describes which items to fetch with FilterTodos.
+// Zero values don't filter anything.
+type TodoFilter struct {
+	ProjectId   int
+	Folder      string
+	Branch      string
+	Pending     bool
+	Done        bool
+	Uncommitted bool
+	Since       string
+	Text        string
+	DueBy       string
+}
+
+// likeEscaper escapes the wildcards of the text matched with "like"
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// FilterTodos reads items matching the filter ordered by repository,
+// branch and position, and calls f with every item and its project.
+// Status filters (pending, done, uncommitted) are combined with "or".
+func (tdb *TodoDb) FilterTodos(filter TodoFilter, f func(p Project, t Todo)) error {
+	where := []string{"1=1"}
+	args := map[string]any{}
+
+	if filter.ProjectId != 0 {
+		where = append(where, "t.project_id = :projId")
+		args["projId"] = filter.ProjectId
+	}
+
+	if filter.Folder != "" {
+		where = append(where, "p.folder = :folder")
+		args["folder"] = filter.Folder
+	}
+
+	if filter.Branch != "" {
+		where = append(where, "p.branch = :branch")
+		args["branch"] = filter.Branch
+	}
+
+	status := []string{}
+	if filter.Pending {
+		status = append(status, "t.done_at is null")
+	}
+	if filter.Done {
+		status = append(status, "t.done_at is not null")
+	}
+	if filter.Uncommitted {
+		status = append(status, "(t.done_at is not null and t.committed_at is null)")
+	}
+	if len(status) > 0 {
+		where = append(where, "("+strings.Join(status, " or ")+")")
+	}
+
+	if filter.Since != "" {
+		where = append(where, "(t.created_at >= :since or t.done_at >= :since)")
+		args["since"] = filter.Since
+	}
+
+	if filter.DueBy != "" {
+		where = append(where, "t.due_at <= :dueBy")
+		args["dueBy"] = filter.DueBy
+	}
+
+	if filter.Text != "" {
+		where = append(where, `(t.task like '%' || :text || '%' escape '\' or t.notes like '%' || :text || '%' escape '\')`)
+		args["text"] = likeEscaper.Replace(filter.Text)
+	}
+
+	query := `select t.todo_id, t.project_id, t.task, t.position, t.created_at, t.done_at, t.committed_at, t.parent_id, t.notes, t.due_at, t.estimate, t.source, t.source_text,
+	p.folder, p.branch, p.name, p.ticket
+	from todo t natural join project p
+	where ` + strings.Join(where, " and ") + `
+	order by p.folder, p.branch = '*', p.branch, t.position`
+
+	rows, err := tdb.db.NamedQuery(query, args)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var row struct {
+		Id          int            `db:"todo_id"`
+		ProjectId   int            `db:"project_id"`
+		Task        string         `db:"task"`
+		Position    int            `db:"position"`
+		CreatedAt   string         `db:"created_at"`
+		DoneAt      sql.NullString `db:"done_at"`
+		CommittedAt sql.NullString `db:"committed_at"`
+		ParentId    sql.NullInt64  `db:"parent_id"`
+		Notes       string         `db:"notes"`
+		DueAt       sql.NullString `db:"due_at"`
+		Estimate    sql.NullInt64  `db:"estimate"`
+		Source      sql.NullString `db:"source"`
+		SourceText  sql.NullString `db:"source_text"`
+		Folder      string         `db:"folder"`
+		Branch      string         `db:"branch"`
+		Name        string         `db:"name"`
+		Ticket      string         `db:"ticket"`
+	}
+
+	for rows.Next() {
+		if err = rows.StructScan(&row); err != nil {
+			return err
+		}
+		f(
+			Project{Id: row.ProjectId, Folder: row.Folder, Branch: row.Branch, Name: row.Name, Ticket: row.Ticket},
+			Todo{
+				Id:          row.Id,
+				ProjectId:   row.ProjectId,
+				Task:        row.Task,
+				Position:    row.Position,
+				CreatedAt:   row.CreatedAt,
+				DoneAt:      row.DoneAt,
+				CommittedAt: row.CommittedAt,
+				ParentId:    row.ParentId,
+				Notes:       row.Notes,
+				DueAt:       row.DueAt,
+				Estimate:    row.Estimate,
+				Source:      row.Source,
+				SourceText:  row.SourceText,
+			},
+		)
+	}
+
+	return rows.Err()
+}
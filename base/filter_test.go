@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestFilterTodos(t *testing.T) {
+	db, err := NewTodoDbSrc("file:filter.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	feat := db.FetchProjectId("/tmp/repo", "feat")
+	db.AddTodos(main, []string{"write code", "write tests", "release"})
+	db.AddTodos(feat, []string{"write docs"})
+
+	doneId, _ := db.AddTodo(main, "review")
+	db.TodoDone(doneId, true)
+	db.SetDue(doneId, "2025-01-01")
+
+	dueId, _ := db.AddTodo(feat, "fix bug")
+	db.SetDue(dueId, "2025-01-02")
+
+	other := db.FetchProjectId("/tmp/repo", "other")
+	db.AddTodos(other, []string{"ship 50% of it", "ship 5 of it"})
+
+	tests := []struct {
+		name     string
+		filter   TodoFilter
+		expected []string
+	}{
+		{"project", TodoFilter{ProjectId: feat}, []string{"write docs", "fix bug"}},
+		{"folder and text", TodoFilter{Folder: "/tmp/repo", Text: "WRITE"}, []string{"write docs", "write code", "write tests"}},
+		{"done", TodoFilter{ProjectId: main, Done: true}, []string{"review"}},
+		{"pending", TodoFilter{ProjectId: main, Pending: true, Text: "e"}, []string{"write code", "write tests", "release"}},
+		{"since", TodoFilter{Since: "2999-01-01 00:00:00"}, []string{}},
+		{"due", TodoFilter{DueBy: "2025-01-05"}, []string{"fix bug", "review"}},
+		{"pending and due", TodoFilter{Pending: true, DueBy: "2025-01-05"}, []string{"fix bug"}},
+		{"branch", TodoFilter{Folder: "/tmp/repo", Branch: "feat"}, []string{"write docs", "fix bug"}},
+		{"missing branch", TodoFilter{Folder: "/tmp/repo", Branch: "nope"}, []string{}},
+		{"percent", TodoFilter{Text: "0%"}, []string{"ship 50% of it"}},
+		{"percent wildcard", TodoFilter{Text: "5%"}, []string{}},
+		{"underscore", TodoFilter{Text: "5_"}, []string{}},
+	}
+
+	for _, test := range tests {
+		got := []string{}
+		err := db.FilterTodos(test.filter, func(p Project, t Todo) {
+			got = append(got, t.Task)
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != len(test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"errors"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// kinds of timesheet issues
+const (
+	TimesheetUnpairedStart int = iota + 1
+	TimesheetUnpairedStop
+	TimesheetOutOfOrder
+)
+
+type PositionIssue struct {
+	ProjectId   int `db:"project_id"`
+	ItemCount   int `db:"item_count"`
+	UniqueCount int `db:"unique_count"`
+	MinPosition int `db:"min_position"`
+	MaxPosition int `db:"max_position"`
+}
+
+type TimesheetIssue struct {
+	Kind  int
+	Entry TimeEntry
+}
+
+// HasDuplicates tells if some of the items share the same position
+func (pi PositionIssue) HasDuplicates() bool {
+	return pi.UniqueCount != pi.ItemCount
+}
+
+// HasGaps tells if the positions are not a continuous sequence starting with 1
+func (pi PositionIssue) HasGaps() bool {
+	return pi.MinPosition != 1 || pi.MaxPosition != pi.UniqueCount
+}
+
+// CheckPositions finds projects where item positions are not
+// a continuous sequence of unique numbers starting with 1
+func (tdb *TodoDb) CheckPositions() ([]PositionIssue, error) {
+	var resultSet []PositionIssue
+	sql := `select project_id, count(*) as item_count, count(distinct position) as unique_count,
+	min(position) as min_position, max(position) as max_position
+	from todo group by project_id
+	having count(distinct position) != count(*) or min(position) != 1 or max(position) != count(*)
+	order by project_id`
+
+	if err := tdb.db.Select(&resultSet, sql); err != nil {
+		return nil, err
+	}
+
+	return resultSet, nil
+}
+
+// FixPositions renumbers the items of the project while keeping the
+// current order. Items sharing the same position are ordered by id.
+func (tdb *TodoDb) FixPositions(projId int) error {
+	sql := `with ordered as (
+		select todo_id, row_number() over (order by position, todo_id) as new_position
+		from todo where project_id = $1
+	)
+	update todo set position = (select new_position from ordered where ordered.todo_id = todo.todo_id)
+	where project_id = $1`
+
+	_, err := tdb.db.Exec(sql, projId)
+	return err
+}
+
+// CheckTimesheet finds start and stop entries that can't be paired
+// when the timesheet is read in the chronological order
+func (tdb *TodoDb) CheckTimesheet() ([]TimesheetIssue, error) {
+	var entries []TimeEntry
+	sql := `select timesheet_id, project_id, action, created_at from timesheet
+	order by created_at, timesheet_id`
+
+	if err := tdb.db.Select(&entries, sql); err != nil {
+		return nil, err
+	}
+
+	return checkTimeEntries(entries), nil
+}
+
+// checkTimeEntries walks trough the chronologically sorted entries and
+// reports the ones that break the start/stop sequence. A start entry
+// at the very end is the running timer and it is not reported.
+func checkTimeEntries(entries []TimeEntry) []TimesheetIssue {
+	issues := []TimesheetIssue{}
+	var open *TimeEntry
+
+	for _, e := range entries {
+		switch {
+		case e.Action == TimesheetActionStart && open != nil:
+			issues = append(issues, TimesheetIssue{Kind: TimesheetUnpairedStart, Entry: *open})
+			open = &e
+		case e.Action == TimesheetActionStart:
+			open = &e
+		case open == nil || open.ProjectId != e.ProjectId:
+			issues = append(issues, TimesheetIssue{Kind: TimesheetUnpairedStop, Entry: e})
+		case e.Id < open.Id:
+			// stop recorded before the start, the clock must have changed
+			issues = append(issues,
+				TimesheetIssue{Kind: TimesheetOutOfOrder, Entry: *open},
+				TimesheetIssue{Kind: TimesheetOutOfOrder, Entry: e},
+			)
+			open = nil
+		default:
+			open = nil
+		}
+	}
+
+	return issues
+}
+
+// DeleteTimeEntries deletes timesheet entries by their ids
+func (tdb *TodoDb) DeleteTimeEntries(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	q, args, err := sqlx.In(`delete from timesheet where timesheet_id in (?)`, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = tdb.db.Exec(tdb.db.Rebind(q), args...)
+	return err
+}
+
+// CheckProjectFolders returns projects whose repository folders
+// no longer exist on the disk
+func (tdb *TodoDb) CheckProjectFolders() ([]Project, error) {
+	var projects []Project
+	err := tdb.db.Select(&projects, `select project_id, folder, branch, name, ticket from project order by folder, branch`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []Project{}
+	for _, p := range projects {
+		_, err := os.Stat(p.Folder)
+		if errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, p)
+		}
+	}
+
+	return missing, nil
+}
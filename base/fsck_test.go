@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestCheckTimeEntries(t *testing.T) {
+	entries := []TimeEntry{
+		{Id: 1, ProjectId: 1, Action: TimesheetActionStop, CreatedAt: "2025-01-01 08:00:00"},
+		{Id: 2, ProjectId: 1, Action: TimesheetActionStart, CreatedAt: "2025-01-01 09:00:00"},
+		{Id: 3, ProjectId: 1, Action: TimesheetActionStop, CreatedAt: "2025-01-01 10:00:00"},
+		{Id: 4, ProjectId: 1, Action: TimesheetActionStart, CreatedAt: "2025-01-01 11:00:00"},
+		{Id: 6, ProjectId: 2, Action: TimesheetActionStart, CreatedAt: "2025-01-01 12:00:00"},
+		{Id: 5, ProjectId: 2, Action: TimesheetActionStop, CreatedAt: "2025-01-01 13:00:00"},
+		{Id: 7, ProjectId: 3, Action: TimesheetActionStart, CreatedAt: "2025-01-01 14:00:00"},
+	}
+
+	expected := []TimesheetIssue{
+		{Kind: TimesheetUnpairedStop, Entry: entries[0]},
+		{Kind: TimesheetUnpairedStart, Entry: entries[3]},
+		{Kind: TimesheetOutOfOrder, Entry: entries[4]},
+		{Kind: TimesheetOutOfOrder, Entry: entries[5]},
+	}
+
+	got := checkTimeEntries(entries)
+
+	if len(got) != len(expected) {
+		t.Fatalf("size not equal. expected %d, got %d: %v", len(expected), len(got), got)
+	}
+
+	for i := range expected {
+		if expected[i] != got[i] {
+			t.Errorf("not equal. expected %v, got %v", expected[i], got[i])
+		}
+	}
+}
+
+func TestFixPositions(t *testing.T) {
+	db, err := NewTodoDbSrc("file:fsck.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	projId := db.FetchProjectId("/tmp/repo", "main")
+	_, err = db.db.Exec(`insert into todo (project_id, task, position) values
+		($1, 'c', 5), ($1, 'a', 2), ($1, 'b', 2)`, projId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := db.CheckPositions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 1 || !issues[0].HasDuplicates() || !issues[0].HasGaps() {
+		t.Fatalf("expected duplicates and gaps, got %v", issues)
+	}
+
+	if err = db.FixPositions(projId); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ""
+	db.TodoItems(projId, func(t Todo) { got += t.Task })
+
+	if got != "abc" {
+		t.Errorf("wrong order after fix: %q", got)
+	}
+
+	issues, _ = db.CheckPositions()
+	if len(issues) != 0 {
+		t.Errorf("expected no issues after fix, got %v", issues)
+	}
+}
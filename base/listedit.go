@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "database/sql"
+
+// ListItem is an item of a list edited as a whole. New items have zero id.
+// The task is the full text of the item, including the notes. The level
+// tells how deep the item is nested under the items before it.
+type ListItem struct {
+	Id       int
+	Done     bool
+	Task     string
+	Due      string
+	Estimate int
+	Level    int
+}
+
+// ListPlan holds the changes to be applied to the list of a project
+type ListPlan struct {
+	Items     []ListItem
+	Deleted   []Todo
+	Kept      []Todo
+	Added     int
+	Updated   int
+	Completed int
+	Reopened  int
+	Reordered int
+}
+
+// HasChanges tells if applying the plan would change anything
+func (lp *ListPlan) HasChanges() bool {
+	return lp.Added+lp.Updated+lp.Completed+lp.Reopened+lp.Reordered+len(lp.Deleted) > 0
+}
+
+// PlanListChanges compares the current items of a project with the edited
+// ones and creates a plan of changes. Items are in the order of the edited
+// list, unknown or repeated ids are treated as new items, and missing items
+// are deleted. Completed items can't be deleted, so they are kept at the end
+// as top level items. Items moved under another parent count as reordered.
+func PlanListChanges(current []Todo, edited []ListItem) *ListPlan {
+	plan := &ListPlan{Items: make([]ListItem, 0, len(edited)), Deleted: []Todo{}, Kept: []Todo{}}
+	currentMap := make(map[int]Todo, len(current))
+	seen := make(map[int]struct{}, len(current))
+
+	for _, t := range current {
+		currentMap[t.Id] = t
+	}
+
+	for _, e := range edited {
+		t, ok := currentMap[e.Id]
+		_, repeated := seen[e.Id]
+
+		if !ok || repeated {
+			e.Id = 0
+			plan.Added++
+			plan.Items = append(plan.Items, e)
+			continue
+		}
+
+		seen[e.Id] = struct{}{}
+
+		if e.Task != t.Text() || e.Due != t.DueAt.String || int64(e.Estimate) != t.Estimate.Int64 {
+			plan.Updated++
+		}
+		if e.Done && !t.DoneAt.Valid {
+			plan.Completed++
+		}
+		if !e.Done && t.DoneAt.Valid {
+			plan.Reopened++
+		}
+
+		plan.Items = append(plan.Items, e)
+	}
+
+	for _, t := range current {
+		if _, ok := seen[t.Id]; ok {
+			continue
+		}
+
+		if t.DoneAt.Valid {
+			plan.Kept = append(plan.Kept, t)
+			plan.Items = append(plan.Items, ListItem{Id: t.Id, Done: true, Task: t.Text(), Due: t.DueAt.String, Estimate: int(t.Estimate.Int64)})
+		} else {
+			plan.Deleted = append(plan.Deleted, t)
+		}
+	}
+
+	parents := listParents(plan.Items)
+	for i, item := range plan.Items {
+		parentId := int64(0)
+		if parents[i] >= 0 {
+			parentId = int64(plan.Items[parents[i]].Id)
+		}
+
+		t, ok := currentMap[item.Id]
+		if ok && (t.Position != i+1 || t.ParentId.Int64 != parentId) {
+			plan.Reordered++
+		}
+	}
+
+	return plan
+}
+
+// listParents returns the index of the parent of every item by the levels
+// of the items, or -1 for the top level items
+func listParents(items []ListItem) []int {
+	parents := make([]int, len(items))
+	stack := []int{}
+
+	for i, item := range items {
+		level := min(max(item.Level, 0), len(stack))
+		parents[i] = -1
+		if level > 0 {
+			parents[i] = stack[level-1]
+		}
+		stack = append(stack[:level], i)
+	}
+
+	return parents
+}
+
+// ApplyListPlan applies the plan to the project in a single transaction.
+// Sub-items are nested under the items before them by their levels.
+func (tdb *TodoDb) ApplyListPlan(projId int, plan *ListPlan) error {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range plan.Deleted {
+		if _, err = tx.Exec("delete from todo where todo_id=$1", t.Id); err != nil {
+			return err
+		}
+	}
+
+	// parents come before their sub-items, so the ids of the new ones
+	// are known by the time they are needed
+	ids := make([]int64, len(plan.Items))
+	parents := listParents(plan.Items)
+
+	for i, item := range plan.Items {
+		task, notes := SplitTask(item.Task)
+		ids[i] = int64(item.Id)
+
+		parent := sql.NullInt64{}
+		if parents[i] >= 0 {
+			parent = sql.NullInt64{Int64: ids[parents[i]], Valid: true}
+		}
+
+		if item.Id == 0 {
+			err = tx.Get(&ids[i], `insert into todo (project_id, task, notes, position, parent_id, due_at, estimate, done_at)
+			values ($1, $2, $3, $4, $5, nullif($6, ''), nullif($7, 0), case when $8 then datetime(current_timestamp, 'localtime') end)
+			returning todo_id`,
+				projId, task, notes, i+1, parent, item.Due, item.Estimate, item.Done)
+		} else {
+			_, err = tx.Exec(`update todo set task=$1, notes=$2, position=$3, parent_id=$4, due_at=nullif($5, ''), estimate=nullif($6, 0),
+			done_at=case when $7 then coalesce(done_at, datetime(current_timestamp, 'localtime')) end
+			where todo_id=$8`,
+				task, notes, i+1, parent, item.Due, item.Estimate, item.Done, item.Id)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = normalizePositions(tx, projId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+func TestPlanListChanges(t *testing.T) {
+	done := sql.NullString{String: "2025-01-01 10:00:00", Valid: true}
+	current := []Todo{
+		{Id: 1, Task: "one", Position: 1, DoneAt: done},
+		{Id: 2, Task: "two", Position: 2},
+		{Id: 3, Task: "three", Position: 3},
+		{Id: 4, Task: "four", Position: 4, DoneAt: done},
+		{Id: 5, Task: "five", Position: 5},
+	}
+
+	edited := []ListItem{
+		{Id: 3, Task: "three", Done: true},
+		{Id: 1, Task: "one"},
+		{Id: 2, Task: "two!"},
+		{Id: 2, Task: "two again"},
+		{Id: 99, Task: "unknown"},
+	}
+
+	plan := PlanListChanges(current, edited)
+
+	expectedItems := []ListItem{
+		{Id: 3, Task: "three", Done: true},
+		{Id: 1, Task: "one"},
+		{Id: 2, Task: "two!"},
+		{Task: "two again"},
+		{Task: "unknown"},
+		{Id: 4, Task: "four", Done: true},
+	}
+
+	if !slices.Equal(expectedItems, plan.Items) {
+		t.Errorf("items not equal. expected %v, got %v", expectedItems, plan.Items)
+	}
+
+	if len(plan.Deleted) != 1 || plan.Deleted[0].Id != 5 {
+		t.Errorf("expected item 5 to be deleted, got %v", plan.Deleted)
+	}
+
+	if len(plan.Kept) != 1 || plan.Kept[0].Id != 4 {
+		t.Errorf("expected item 4 to be kept, got %v", plan.Kept)
+	}
+
+	counts := []int{plan.Added, plan.Updated, plan.Completed, plan.Reopened, plan.Reordered}
+	if !slices.Equal(counts, []int{2, 1, 1, 1, 4}) {
+		t.Errorf("wrong counts: %v", counts)
+	}
+}
+
+func TestApplyListPlanTree(t *testing.T) {
+	db, err := NewTodoDbSrc("file:listedittree.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	err = db.AddTodoTree(main, 0, []TodoInput{
+		{Task: "a"},
+		{Task: "b", Level: 1},
+		{Task: "c", Level: 2},
+		{Task: "d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := []Todo{}
+	ids := map[string]int{}
+	db.TodoItems(main, func(t Todo) {
+		current = append(current, t)
+		ids[t.Task] = t.Id
+	})
+
+	// b moves under d, c moves to the top level, and x is added under c
+	plan := PlanListChanges(current, []ListItem{
+		{Id: ids["a"], Task: "a"},
+		{Id: ids["d"], Task: "d"},
+		{Id: ids["b"], Task: "b", Level: 1},
+		{Id: ids["c"], Task: "c"},
+		{Task: "x", Level: 3},
+	})
+
+	if plan.Reordered != 3 || plan.Added != 1 {
+		t.Errorf("wrong counts: reordered %d, added %d", plan.Reordered, plan.Added)
+	}
+
+	if err = db.ApplyListPlan(main, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{" a", " d", " .b", " c", " .x"}
+	if got := treeState(t, db, main); !slices.Equal(expected, got) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -74,6 +74,134 @@ create table timesheet (
 );
 
 create index idx_timesheet on timesheet (project_id, created_at);
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Sub-items",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+alter table todo add column parent_id integer 
+	references todo (todo_id) 
+		on delete set null 
+		on update no action;
+
+create index idx_todo_parent on todo (parent_id) where parent_id is not null;
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Item notes",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+alter table todo add column notes text not null default '';
+
+-- the first line of existing items is the title, and the rest are the notes
+update todo set 
+	task = trim(substr(task, 1, instr(task, char(10)) - 1), char(13) || ' '),
+	notes = trim(substr(task, instr(task, char(10)) + 1), char(10) || char(13) || ' ')
+where instr(task, char(10)) > 0;
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Due dates",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+alter table todo add column due_at text;
+
+create index idx_todo_due on todo (due_at) where due_at is not null;
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Estimates",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+alter table todo add column estimate integer;
+
+-- named differently from todo.estimate because of the natural joins
+alter table project add column total_estimate integer;
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Templates",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+create table template (
+	template_id integer primary key autoincrement,
+	name text not null,
+	branches text not null default '',
+	items text not null,
+	created_at text not null 
+		default (datetime(current_timestamp, 'localtime'))
+);
+
+create unique index idx_template on template (name);
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Project tickets",
+				Func: func(tx *sql.Tx) error {
+					sql := `alter table project add column ticket text not null default '';`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Item sources",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+alter table todo add column source text;
+
+alter table todo add column source_text text;
+`
+					if _, err := tx.Exec(sql); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			&migrator.Migration{
+				Name: "Item uuids",
+				Func: func(tx *sql.Tx) error {
+					sql := `
+create table todo_uuid (
+	uuid text primary key,
+	todo_id integer not null unique,
+	foreign key (todo_id) 
+      references todo (todo_id) 
+         on delete cascade 
+         on update no action
+);
 `
 					if _, err := tx.Exec(sql); err != nil {
 						return err
@@ -19,14 +19,16 @@ package base
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
 )
 
 type Project struct {
-	Id     int    `db:"project_id"`
-	Folder string `db:"folder"`
-	Branch string `db:"branch"`
-	Name   string `db:"name"`
+	Id     int    `db:"project_id" json:"id"`
+	Folder string `db:"folder" json:"repo"`
+	Branch string `db:"branch" json:"branch"`
+	Name   string `db:"name" json:"name"`
+	Ticket string `db:"ticket" json:"ticket"`
 }
 
 type Todo struct {
@@ -37,6 +39,12 @@ type Todo struct {
 	CreatedAt   string         `db:"created_at"`
 	DoneAt      sql.NullString `db:"done_at"`
 	CommittedAt sql.NullString `db:"committed_at"`
+	ParentId    sql.NullInt64  `db:"parent_id"`
+	Notes       string         `db:"notes"`
+	DueAt       sql.NullString `db:"due_at"`
+	Estimate    sql.NullInt64  `db:"estimate"`
+	Source      sql.NullString `db:"source"`
+	SourceText  sql.NullString `db:"source_text"`
 }
 
 type TimeEntry struct {
@@ -47,21 +55,35 @@ type TimeEntry struct {
 }
 
 type ItemAndBranch struct {
+	ItemId     int    `db:"todo_id"`
 	ItemName   string `db:"item_name"`
 	BranchName string `db:"branch_name"`
 }
 
 type BranchItem struct {
-	ProjectId  int    `db:"project_id"`
-	BranchName string `db:"branch_name"`
-	ItemCount  int    `db:"item_count"`
+	ProjectId    int    `db:"project_id"`
+	BranchName   string `db:"branch_name"`
+	ItemCount    int    `db:"item_count"`
+	PendingCount int    `db:"pending_count"`
+	DoneCount    int    `db:"done_count"`
 }
 
 type ReportItem struct {
-	Id        int    `db:"todo_id"`
-	ProjectId int    `db:"project_id"`
-	Task      string `db:"task"`
-	TimeAt    string `db:"time_at"`
+	Id        int            `db:"todo_id"`
+	ProjectId int            `db:"project_id"`
+	Task      string         `db:"task"`
+	TimeAt    string         `db:"time_at"`
+	DueAt     sql.NullString `db:"due_at"`
+	Estimate  sql.NullInt64  `db:"estimate"`
+}
+
+// ReportEstimate compares the estimate of a completed item
+// with the time tracked while it was worked on, see ItemTimes
+type ReportEstimate struct {
+	Id          int    `json:"id"`
+	Task        string `json:"task"`
+	EstimateSec int    `json:"estimate_sec"`
+	ActualSec   int    `json:"actual_sec"`
 }
 
 type ReportTimeEntry struct {
@@ -76,8 +98,11 @@ type ReportProject struct {
 	Proj             *Project
 	CompletedItems   []ReportItem
 	CreatedItems     []ReportItem
+	DueItems         []ReportItem
 	TimeEntries      []ReportTimeEntry
 	TotalTimeSeconds int
+	Estimate         *EstimateSummary
+	Estimates        []ReportEstimate
 	LatestUpdate     string
 	TimerRunning     bool
 }
@@ -116,14 +141,96 @@ func (ts *TimeEntry) Duration() int {
 	return int(time.Since(since).Seconds())
 }
 
+// Text returns the title of the item followed by the notes, if any
+func (t Todo) Text() string {
+	if t.Notes == "" {
+		return t.Task
+	}
+	return t.Task + "\n\n" + t.Notes
+}
+
+// IsOverdue tells if the item is not done and the due date is before today,
+// where today is a date in YYYY-MM-DD format
+func (t Todo) IsOverdue(today string) bool {
+	return !t.DoneAt.Valid && t.DueAt.Valid && t.DueAt.String < today
+}
+
+// SplitTask splits the text of an item into the title,
+// which is the first line, and the notes, which are the rest
+func SplitTask(text string) (title, notes string) {
+	title, notes, _ = strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(notes)
+}
+
+func (t Todo) MarshalJSON() ([]byte, error) {
+	var doneAt, committedAt *string
+	var parentId *int64
+	var dueAt *string
+	var estimate *int64
+	var source *string
+
+	if t.DoneAt.Valid {
+		s := FormatUTC(t.DoneAt.String)
+		doneAt = &s
+	}
+	if t.CommittedAt.Valid {
+		s := FormatUTC(t.CommittedAt.String)
+		committedAt = &s
+	}
+	if t.ParentId.Valid {
+		parentId = &t.ParentId.Int64
+	}
+	if t.DueAt.Valid {
+		dueAt = &t.DueAt.String
+	}
+	if t.Estimate.Valid {
+		estimate = &t.Estimate.Int64
+	}
+	if t.Source.Valid {
+		source = &t.Source.String
+	}
+
+	return json.Marshal(struct {
+		Id          int     `json:"id"`
+		Task        string  `json:"task"`
+		Position    int     `json:"position"`
+		CreatedAt   string  `json:"created_at"`
+		DoneAt      *string `json:"done_at"`
+		CommittedAt *string `json:"committed_at"`
+		ParentId    *int64  `json:"parent_id"`
+		Notes       string  `json:"notes"`
+		DueAt       *string `json:"due_at"`
+		EstimateSec *int64  `json:"estimate_sec"`
+		Source      *string `json:"source"`
+	}{
+		Id:          t.Id,
+		Task:        t.Task,
+		Position:    t.Position,
+		CreatedAt:   FormatUTC(t.CreatedAt),
+		DoneAt:      doneAt,
+		CommittedAt: committedAt,
+		ParentId:    parentId,
+		Notes:       t.Notes,
+		DueAt:       dueAt,
+		EstimateSec: estimate,
+		Source:      source,
+	})
+}
+
 func (ri ReportItem) MarshalJSON() ([]byte, error) {
 	t, _ := time.ParseInLocation(time.DateTime, ri.TimeAt, time.Local)
 
+	var dueAt *string
+	if ri.DueAt.Valid {
+		dueAt = &ri.DueAt.String
+	}
+
 	return json.Marshal(struct {
-		Id     int    `json:"id"`
-		Task   string `json:"task"`
-		TimeAt string `json:"at"`
-	}{Id: ri.Id, Task: ri.Task, TimeAt: t.UTC().Format(time.RFC3339)})
+		Id     int     `json:"id"`
+		Task   string  `json:"task"`
+		TimeAt string  `json:"at"`
+		DueAt  *string `json:"due_at,omitempty"`
+	}{Id: ri.Id, Task: ri.Task, TimeAt: t.UTC().Format(time.RFC3339), DueAt: dueAt})
 }
 
 func (rte ReportTimeEntry) MarshalJSON() ([]byte, error) {
@@ -147,17 +254,25 @@ func (rp *ReportProject) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Name             string            `json:"name"`
 		Branch           string            `json:"branch"`
+		Ticket           string            `json:"ticket"`
 		CompletedItems   []ReportItem      `json:"completed"`
 		CreatedItems     []ReportItem      `json:"created"`
+		DueItems         []ReportItem      `json:"due"`
 		TimeEntries      []ReportTimeEntry `json:"timesheet"`
 		TotalTimeSeconds int               `json:"total_sec"`
+		Estimate         *EstimateSummary  `json:"estimate,omitempty"`
+		Estimates        []ReportEstimate  `json:"estimates"`
 	}{
 		Name:             rp.Proj.Name,
 		Branch:           rp.Proj.Branch,
+		Ticket:           rp.Proj.Ticket,
 		CompletedItems:   rp.CompletedItems,
 		CreatedItems:     rp.CreatedItems,
+		DueItems:         rp.DueItems,
 		TimeEntries:      rp.TimeEntries,
 		TotalTimeSeconds: rp.TotalTimeSeconds,
+		Estimate:         rp.Estimate,
+		Estimates:        rp.Estimates,
 	})
 }
 
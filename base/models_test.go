@@ -0,0 +1,41 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestSplitTask(t *testing.T) {
+	tests := []struct {
+		text, title, notes string
+	}{
+		{"Title only", "Title only", ""},
+		{"  Title  \n\n  Some notes\n\n- and a list\n", "Title", "Some notes\n\n- and a list"},
+		{"\nTitle\r\nnotes", "Title", "notes"},
+	}
+
+	for _, test := range tests {
+		title, notes := SplitTask(test.text)
+		if title != test.title || notes != test.notes {
+			t.Errorf("%q: expected %q and %q, got %q and %q", test.text, test.title, test.notes, title, notes)
+		}
+
+		todo := Todo{Task: title, Notes: notes}
+		if title2, notes2 := SplitTask(todo.Text()); title2 != title || notes2 != notes {
+			t.Errorf("%q: text doesn't split back into %q and %q", todo.Text(), title, notes)
+		}
+	}
+}
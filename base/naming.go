@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NameRules are regular expressions matched against the names of new
+// branches, where the named groups "ticket" and "name" capture the ticket
+// id and the name of the project, i.e. ^feature/(?P<ticket>[A-Z]+-\d+)-(?P<name>.+)$
+type NameRules []*regexp.Regexp
+
+// ParseNameRules compiles the rules, in the order of precedence
+func ParseNameRules(rules []string) (NameRules, error) {
+	result := make(NameRules, 0, len(rules))
+
+	for _, r := range rules {
+		re, err := regexp.Compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid name rule %q: %w", r, err)
+		}
+		result = append(result, re)
+	}
+
+	return result, nil
+}
+
+// Apply returns the name and the ticket id of the branch from the first rule
+// that matches it. The name is made from the captured slug by replacing the
+// hyphens and underscores with spaces, and it's the branch name if not captured.
+func (nr NameRules) Apply(branch string) (name, ticket string) {
+	name = branch
+
+	for _, re := range nr {
+		m := re.FindStringSubmatch(branch)
+		if m == nil {
+			continue
+		}
+
+		if i := re.SubexpIndex("ticket"); i > 0 {
+			ticket = m[i]
+		}
+		if i := re.SubexpIndex("name"); i > 0 && m[i] != "" {
+			name = humanizeSlug(m[i])
+		}
+		break
+	}
+
+	return name, ticket
+}
+
+// SetNameRules sets the rules used for naming the new projects
+func (tdb *TodoDb) SetNameRules(rules NameRules) {
+	tdb.nameRules = rules
+}
+
+// humanizeSlug turns a slug like "fix-login_page" into "Fix login page"
+func humanizeSlug(slug string) string {
+	s := strings.Join(strings.FieldsFunc(slug, func(r rune) bool {
+		return r == '-' || r == '_' || unicode.IsSpace(r)
+	}), " ")
+
+	if s == "" {
+		return slug
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestNameRules(t *testing.T) {
+	rules, err := ParseNameRules([]string{
+		`^\w+/(?P<ticket>[A-Z]+-\d+)-(?P<name>.+)$`,
+		`^(?P<ticket>[A-Z]+-\d+)$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		branch, name, ticket string
+	}{
+		{"feature/PROJ-123-some_slug", "Some slug", "PROJ-123"},
+		{"PROJ-7", "PROJ-7", "PROJ-7"},
+		{"main", "main", ""},
+	}
+
+	for _, test := range tests {
+		name, ticket := rules.Apply(test.branch)
+		if name != test.name || ticket != test.ticket {
+			t.Errorf("%s: expected %q and %q, got %q and %q", test.branch, test.name, test.ticket, name, ticket)
+		}
+	}
+
+	if _, err := ParseNameRules([]string{"(unclosed"}); err == nil {
+		t.Error("expected an error for an invalid rule")
+	}
+
+	db, err := NewTodoDbSrc("file:naming.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	db.SetNameRules(rules)
+	proj := db.GetProject(db.FetchProjectId("/tmp/repo", "fix/ABC-1-login-page"))
+
+	if proj.Name != "Login page" || proj.Ticket != "ABC-1" {
+		t.Errorf("unexpected project %v", proj)
+	}
+
+	// the rules are applied only on creation
+	db.UpdateProjectName(proj.Id, "Custom")
+	if p := db.GetProject(db.FetchProjectId("/tmp/repo", "fix/ABC-1-login-page")); p.Name != "Custom" {
+		t.Errorf("expected the name to be kept, got %q", p.Name)
+	}
+}
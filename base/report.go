@@ -35,6 +35,7 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 			reportProj = &ReportProject{
 				CompletedItems: []ReportItem{},
 				CreatedItems:   []ReportItem{},
+				DueItems:       []ReportItem{},
 				TimeEntries:    []ReportTimeEntry{},
 			}
 			projectMap[item.ProjectId] = reportProj
@@ -55,6 +56,7 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 			reportProj = &ReportProject{
 				CompletedItems: []ReportItem{},
 				CreatedItems:   []ReportItem{},
+				DueItems:       []ReportItem{},
 				TimeEntries:    []ReportTimeEntry{},
 			}
 			projectMap[item.ProjectId] = reportProj
@@ -67,6 +69,24 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 		return nil, err
 	}
 
+	// crunch items due by the end of the period
+	if err := tdb.reportDueItems(to, folderFilter, func(item ReportItem) {
+		reportProj := projectMap[item.ProjectId]
+		// create project if missing
+		if reportProj == nil {
+			reportProj = &ReportProject{
+				CompletedItems: []ReportItem{},
+				CreatedItems:   []ReportItem{},
+				DueItems:       []ReportItem{},
+				TimeEntries:    []ReportTimeEntry{},
+			}
+			projectMap[item.ProjectId] = reportProj
+		}
+		reportProj.DueItems = append(reportProj.DueItems, item)
+	}); err != nil {
+		return nil, err
+	}
+
 	// crunch time entries
 	if err := tdb.reportTimeEntries(from, to, folderFilter, func(entry ReportTimeEntry) {
 		reportProj := projectMap[entry.ProjectId]
@@ -75,6 +95,7 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 			reportProj = &ReportProject{
 				CompletedItems: []ReportItem{},
 				CreatedItems:   []ReportItem{},
+				DueItems:       []ReportItem{},
 				TimeEntries:    []ReportTimeEntry{},
 			}
 			projectMap[entry.ProjectId] = reportProj
@@ -128,6 +149,10 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 
 		repo.TotalTimeSeconds += p.TotalTimeSeconds
 		report.TotalTimeSeconds += p.TotalTimeSeconds
+
+		if err := tdb.reportEstimates(p); err != nil {
+			return nil, err
+		}
 	}
 
 	report.Repos = slices.Collect(maps.Values(repoMap))
@@ -149,8 +174,45 @@ func (tdb *TodoDb) CreateReport(from, to, folderFilter string) (*Report, error)
 	return report, nil
 }
 
+// reportEstimates compares the estimates of the project and of its
+// completed items with the tracked time
+func (tdb *TodoDb) reportEstimates(p *ReportProject) error {
+	p.Estimates = []ReportEstimate{}
+
+	es, err := tdb.GetEstimateSummary(p.Proj.Id)
+	if err != nil {
+		return err
+	}
+	if es.TotalSec() > 0 {
+		p.Estimate = es
+	}
+
+	var times map[int]int
+
+	for _, item := range p.CompletedItems {
+		if !item.Estimate.Valid {
+			continue
+		}
+
+		if times == nil {
+			if times, err = tdb.ItemTimes(p.Proj.Id); err != nil {
+				return err
+			}
+		}
+
+		p.Estimates = append(p.Estimates, ReportEstimate{
+			Id:          item.Id,
+			Task:        item.Task,
+			EstimateSec: int(item.Estimate.Int64),
+			ActualSec:   times[item.Id],
+		})
+	}
+
+	return nil
+}
+
 func (tdb *TodoDb) reportCompletedItems(from, to, folderFilter string, f func(r ReportItem)) error {
-	sql := `select t.todo_id, t.project_id, t.task, t.done_at as time_at from todo t
+	sql := `select t.todo_id, t.project_id, t.task, t.done_at as time_at, t.due_at, t.estimate from todo t
 	natural join project p
 	where t.done_at >= ? and t.done_at <= ? and p.folder like ? || '%' and p.branch != '*'
 	order by t.project_id, t.done_at`
@@ -173,7 +235,7 @@ func (tdb *TodoDb) reportCompletedItems(from, to, folderFilter string, f func(r
 }
 
 func (tdb *TodoDb) reportCreatedItems(from, to, folderFilter string, f func(r ReportItem)) error {
-	sql := `select t.todo_id, t.project_id, t.task, t.created_at as time_at from todo t 
+	sql := `select t.todo_id, t.project_id, t.task, t.created_at as time_at, t.due_at, t.estimate from todo t 
 	natural join project p
 	where t.created_at >= ? and t.created_at <= ? and t.done_at is null 
 	and p.folder like ? || '%' and p.branch != '*'
@@ -196,6 +258,31 @@ func (tdb *TodoDb) reportCreatedItems(from, to, folderFilter string, f func(r Re
 	return nil
 }
 
+// reportDueItems reads pending items that are due by the given time
+func (tdb *TodoDb) reportDueItems(to, folderFilter string, f func(r ReportItem)) error {
+	sql := `select t.todo_id, t.project_id, t.task, t.created_at as time_at, t.due_at, t.estimate from todo t 
+	natural join project p
+	where t.due_at <= substr(?, 1, 10) and t.done_at is null 
+	and p.folder like ? || '%' and p.branch != '*'
+	order by t.project_id, t.due_at, t.position`
+
+	rows, err := tdb.db.Queryx(sql, to, folderFilter)
+	if err != nil {
+		return err
+	}
+
+	var item ReportItem
+	for rows.Next() {
+		err = rows.StructScan(&item)
+		if err != nil {
+			return err
+		}
+		f(item)
+	}
+
+	return nil
+}
+
 func (tdb *TodoDb) reportTimeEntries(from, to, folderFilter string, f func(t ReportTimeEntry)) error {
 	sql := `
 	with entries as (
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Template is a named list of items to be added to branches, written in
+// the same format as in the editor. Branches holds the space separated
+// patterns of the branch names that the template is applied to
+// automatically. Templates read from a file have the file set.
+type Template struct {
+	Id       int    `db:"template_id" json:"-"`
+	Name     string `db:"name" json:"name"`
+	Branches string `db:"branches" json:"branches"`
+	Items    string `db:"items" json:"items"`
+	File     string `db:"-" json:"file,omitempty"`
+}
+
+// MatchesBranch tells if the branch name matches
+// any of the branch patterns of the template
+func (t *Template) MatchesBranch(branch string) bool {
+	for _, pattern := range strings.Fields(t.Branches) {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTemplates reads all templates ordered by name
+func (tdb *TodoDb) GetTemplates() ([]Template, error) {
+	templates := []Template{}
+	err := tdb.db.Select(&templates, "select template_id, name, branches, items from template order by name")
+	return templates, err
+}
+
+// SaveTemplate creates the template or replaces the one with the same name
+func (tdb *TodoDb) SaveTemplate(t *Template) error {
+	_, err := tdb.db.Exec(`insert into template (name, branches, items) values ($1, $2, $3)
+	on conflict (name) do update set branches=excluded.branches, items=excluded.items`,
+		t.Name, t.Branches, t.Items)
+	return err
+}
+
+// DeleteTemplate deletes the template by name
+func (tdb *TodoDb) DeleteTemplate(name string) error {
+	res, err := tdb.db.Exec("delete from template where name = $1", name)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("Template %q not found.", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestTemplates(t *testing.T) {
+	db, err := NewTodoDbSrc("file:template.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	if err = db.SaveTemplate(&Template{Name: "release", Branches: "release/* hotfix/*", Items: "- one"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.SaveTemplate(&Template{Name: "release", Items: "- two"}); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := db.GetTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].Items != "- two" || templates[0].Branches != "" {
+		t.Errorf("expected the template to be replaced, got %v", templates)
+	}
+
+	if err = db.DeleteTemplate("release"); err != nil {
+		t.Error(err)
+	}
+	if err = db.DeleteTemplate("release"); err == nil {
+		t.Error("expected an error for a missing template")
+	}
+}
+
+func TestTemplateMatchesBranch(t *testing.T) {
+	tpl := Template{Branches: "release/* hotfix-*"}
+
+	tests := map[string]bool{
+		"release/1.0":  true,
+		"hotfix-login": true,
+		"release":      false,
+		"feat/release": false,
+		"release/1/2":  false,
+	}
+
+	for branch, expected := range tests {
+		if tpl.MatchesBranch(branch) != expected {
+			t.Errorf("%s: expected %v", branch, expected)
+		}
+	}
+}
@@ -41,7 +41,7 @@ func (e *TimerError) Error() string {
 // GetLatestTimeEntry returns the last recorded time entry if found
 func (tdb *TodoDb) GetLatestTimeEntry() *TimeEntry {
 	sql := `select timesheet_id, project_id, action, created_at from timesheet
-	order by created_at desc limit 1`
+	order by created_at desc, timesheet_id desc limit 1`
 	row := tdb.db.QueryRowx(sql)
 	ts := TimeEntry{}
 	err := row.StructScan(&ts)
@@ -58,7 +58,7 @@ func (tdb *TodoDb) CheckTimer(projId int) (*TimeEntry, error) {
 	last := tdb.GetLatestTimeEntry()
 
 	if last != nil && last.ProjectId != projId && last.Action == TimesheetActionStart {
-		proj := tdb.GetProject(projId)
+		proj := tdb.GetProject(last.ProjectId)
 		return last, &TimerRunningElsewhereError{Proj: &proj, Entry: last}
 	}
 
@@ -124,7 +124,7 @@ func (tdb *TodoDb) GetProjectTime(projId int) (int, error) {
 	// the amount of days.
 	sql := `select round(coalesce((
 		(select sum(case when action=1 then -julianday(created_at) else julianday(created_at) end) from timesheet where project_id=$1) +
-		(select case when action=1 then julianday('now', 'localtime') else 0 end from timesheet where project_id=$2 order by created_at desc limit 1)
+		(select case when action=1 then julianday('now', 'localtime') else 0 end from timesheet where project_id=$2 order by created_at desc, timesheet_id desc limit 1)
 	),0)*86400)`
 
 	row := tdb.db.QueryRowx(sql, projId, projId)
@@ -136,3 +136,42 @@ func (tdb *TodoDb) GetProjectTime(projId int) (int, error) {
 func FormatSeconds(s int) string {
 	return fmt.Sprintf("%02d:%02d:%02d", s/3600, (s%3600)/60, s%60)
 }
+
+// FormatUTC converts a local timestamp from the database into
+// a RFC3339 string in UTC, as used in JSON outputs
+func FormatUTC(dateTime string) string {
+	t, _ := time.ParseInLocation(time.DateTime, dateTime, time.Local)
+	return t.UTC().Format(time.RFC3339)
+}
+
+// GetSessions returns the timer sessions of the project that ended after
+// the given time, including the running one that ends now
+func (tdb *TodoDb) GetSessions(projId int, since string) ([]ReportTimeEntry, error) {
+	sql := `
+	with entries as (
+		select project_id, action, created_at as stopped_at,
+		lag(created_at) over (order by created_at, timesheet_id) as started_at from timesheet
+		where project_id = ?
+	)
+	select project_id, started_at, stopped_at, round((julianday(stopped_at)-julianday(started_at))*86400) as duration
+	from entries where action = 2 and started_at is not null and stopped_at >= ?
+	order by stopped_at`
+
+	sessions := []ReportTimeEntry{}
+	if err := tdb.db.Select(&sessions, sql, projId, since); err != nil {
+		return nil, err
+	}
+
+	te := tdb.GetLatestTimeEntry()
+	if te != nil && te.ProjectId == projId && te.Action == TimesheetActionStart {
+		sessions = append(sessions, ReportTimeEntry{
+			ProjectId:   projId,
+			From:        te.CreatedAt,
+			To:          time.Now().Format(time.DateTime),
+			DurationSec: te.Duration(),
+			Running:     true,
+		})
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import "testing"
+
+func TestGetSessions(t *testing.T) {
+	db, err := NewTodoDbSrc("file:sessions.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	feat := db.FetchProjectId("/tmp/repo", "feat")
+
+	entries := []struct {
+		projId, action int
+		at             string
+	}{
+		{main, TimesheetActionStart, "2025-01-01 22:00:00"},
+		{main, TimesheetActionStop, "2025-01-01 23:00:00"},
+		{main, TimesheetActionStart, "2025-01-01 23:30:00"},
+		{main, TimesheetActionStop, "2025-01-02 00:30:00"},
+		{feat, TimesheetActionStart, "2025-01-02 08:00:00"},
+		{feat, TimesheetActionStop, "2025-01-02 09:00:00"},
+		{main, TimesheetActionStart, "2025-01-02 10:00:00"},
+		{main, TimesheetActionStop, "2025-01-02 10:15:00"},
+	}
+	for _, e := range entries {
+		_, err := db.db.Exec("insert into timesheet (project_id, action, created_at) values (?, ?, ?)", e.projId, e.action, e.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sessions, err := db.GetSessions(main, "2025-01-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ReportTimeEntry{
+		{ProjectId: main, From: "2025-01-01 23:30:00", To: "2025-01-02 00:30:00", DurationSec: 3600},
+		{ProjectId: main, From: "2025-01-02 10:00:00", To: "2025-01-02 10:15:00", DurationSec: 900},
+	}
+
+	if len(sessions) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, sessions)
+	}
+	for i := range expected {
+		if sessions[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], sessions[i])
+		}
+	}
+
+	if _, err := db.StartTimer(main); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err = db.GetSessions(main, "2025-01-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 3 || !sessions[2].Running {
+		t.Errorf("expected a running session, got %v", sessions)
+	}
+}
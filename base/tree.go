@@ -0,0 +1,239 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TodoInput is a new item to be added, where the level tells
+// how deep the item is nested under the items before it. The first
+// line of the task is the title, and the rest are the notes. The source
+// is the file:line reference of the comment the item was made from.
+// Creation and completion times are given when the items are imported,
+// otherwise the item is created now and is not completed. The uuid links
+// the item to the one it was imported from, see ItemUUIDs.
+type TodoInput struct {
+	Task       string
+	Level      int
+	Due        string
+	Estimate   int
+	Source     string
+	SourceText string
+	CreatedAt  string
+	DoneAt     string
+	UUID       string
+}
+
+// normalizePositions renumbers the items of the project so that every item
+// is directly followed by its sub-items, while keeping the order of siblings.
+// Items whose parent is not in the same project are treated as top level items.
+func normalizePositions(tx *sqlx.Tx, projId int) error {
+	_, err := tx.Exec(`with recursive tree(todo_id, path, depth) as (
+		select t.todo_id, printf('%010d%010d', t.position, t.todo_id), 0
+		from todo t
+		where t.project_id = $1 and (t.parent_id is null or not exists (
+			select 1 from todo p where p.todo_id = t.parent_id and p.project_id = t.project_id
+		))
+		union all
+		select c.todo_id, tree.path || '.' || printf('%010d%010d', c.position, c.todo_id), tree.depth + 1
+		from todo c join tree on c.parent_id = tree.todo_id
+		where c.project_id = $1 and tree.depth < 100
+	), ordered as (
+		select todo_id, row_number() over (order by path) as new_position from tree
+	)
+	update todo set position = (select new_position from ordered where ordered.todo_id = todo.todo_id)
+	where project_id = $1 and todo_id in (select todo_id from ordered)`, projId)
+	return err
+}
+
+// AddTodoTree adds nested items at the end of the project. Items with
+// level 0 are added under the parent item if parentId is not zero, or as
+// top level items otherwise.
+func (tdb *TodoDb) AddTodoTree(projId, parentId int, items []TodoInput) error {
+	if len(items) == 0 {
+		return errors.New("No items to add.")
+	}
+
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err = tx.Get(&count, "select count(*) from todo where project_id = $1", projId); err != nil {
+		return err
+	}
+
+	root := sql.NullInt64{Int64: int64(parentId), Valid: parentId != 0}
+	parents := []sql.NullInt64{}
+
+	for i, item := range items {
+		level := min(max(item.Level, 0), len(parents))
+		parent := root
+		if level > 0 {
+			parent = parents[level-1]
+		}
+
+		var id int64
+		task, notes := SplitTask(item.Task)
+		err = tx.Get(&id, `insert into todo (project_id, task, notes, position, parent_id, due_at, estimate, source, source_text, created_at, done_at)
+		values ($1, $2, $3, $4, $5, nullif($6, ''), nullif($7, 0), nullif($8, ''), nullif($9, ''),
+		coalesce(nullif($10, ''), datetime(current_timestamp, 'localtime')), nullif($11, '')) returning todo_id`,
+			projId, task, notes, count+i+1, parent, item.Due, item.Estimate, item.Source, item.SourceText, item.CreatedAt, item.DoneAt)
+
+		if err != nil {
+			return err
+		}
+
+		if item.UUID != "" {
+			if _, err = tx.Exec("insert into todo_uuid (uuid, todo_id) values ($1, $2)", item.UUID, id); err != nil {
+				return err
+			}
+		}
+
+		parents = append(parents[:level], sql.NullInt64{Int64: id, Valid: true})
+	}
+
+	if parentId != 0 {
+		if err = normalizePositions(tx, projId); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetParent places the item under another item of the same project,
+// or makes it a top level item if parentId is zero. The item will be
+// placed according to its current position among the new siblings.
+func (tdb *TodoDb) SetParent(todoId, parentId int) error {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var projId int
+	if err = tx.Get(&projId, "select project_id from todo where todo_id = $1", todoId); err != nil {
+		return err
+	}
+
+	parent := sql.NullInt64{}
+
+	if parentId != 0 {
+		// the parent must be in the same project and not be a sub-item of the item
+		var invalid bool
+		err = tx.Get(&invalid, `with recursive ancestors(todo_id) as (
+			select $1
+			union
+			select t.parent_id from todo t join ancestors a on t.todo_id = a.todo_id
+			where t.parent_id is not null
+		)
+		select coalesce((select project_id from todo where todo_id = $1), 0) != $2
+		or exists (select 1 from ancestors where todo_id = $3)`, parentId, projId, todoId)
+
+		if err != nil {
+			return err
+		}
+		if invalid {
+			return errors.New("The item can't be placed under the given item.")
+		}
+
+		parent = sql.NullInt64{Int64: int64(parentId), Valid: true}
+	}
+
+	if _, err = tx.Exec("update todo set parent_id = $1 where todo_id = $2", parent, todoId); err != nil {
+		return err
+	}
+
+	if err = normalizePositions(tx, projId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ItemLevels calculates how deep each of the items is nested,
+// considering only the parents that are in the given list
+func ItemLevels(items []Todo) map[int]int {
+	parents := make(map[int]int, len(items))
+	levels := make(map[int]int, len(items))
+
+	for _, t := range items {
+		parents[t.Id] = 0
+		if t.ParentId.Valid {
+			parents[t.Id] = int(t.ParentId.Int64)
+		}
+	}
+
+	for _, t := range items {
+		level := 0
+		for p := parents[t.Id]; p != 0 && level < len(items); p = parents[p] {
+			if _, ok := parents[p]; !ok {
+				break
+			}
+			level++
+		}
+		levels[t.Id] = level
+	}
+
+	return levels
+}
+
+// SortAsTree orders the items so that sub-items follow their parents,
+// while keeping the order of the items otherwise. Only the parents
+// that are in the given list are considered.
+func SortAsTree(items []Todo) []Todo {
+	present := make(map[int]struct{}, len(items))
+	for _, t := range items {
+		present[t.Id] = struct{}{}
+	}
+
+	children := make(map[int][]Todo)
+	roots := []Todo{}
+
+	for _, t := range items {
+		parentId := int(t.ParentId.Int64)
+		if _, ok := present[parentId]; ok && t.ParentId.Valid && parentId != t.Id {
+			children[parentId] = append(children[parentId], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	sorted := make([]Todo, 0, len(items))
+	var walk func(t Todo)
+	walk = func(t Todo) {
+		sorted = append(sorted, t)
+		for _, c := range children[t.Id] {
+			walk(c)
+		}
+	}
+
+	for _, t := range roots {
+		walk(t)
+	}
+
+	return sorted
+}
@@ -0,0 +1,275 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func treeState(t *testing.T, db *TodoDb, projId int) []string {
+	t.Helper()
+	items := []Todo{}
+	if err := db.TodoItems(projId, func(t Todo) { items = append(items, t) }); err != nil {
+		t.Fatal(err)
+	}
+
+	levels := ItemLevels(items)
+	state := make([]string, len(items))
+	for i, item := range items {
+		done := " "
+		if item.DoneAt.Valid {
+			done = "x"
+		}
+		state[i] = done + strings.Repeat(".", levels[item.Id]) + item.Task
+	}
+	return state
+}
+
+func TestTodoTree(t *testing.T) {
+	db, err := NewTodoDbSrc("file:tree.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	queue := db.FetchProjectId("/tmp/repo", "*")
+
+	err = db.AddTodoTree(main, 0, []TodoInput{
+		{Task: "a"},
+		{Task: "b", Level: 1},
+		{Task: "c", Level: 5},
+		{Task: "d", Level: 1},
+		{Task: "e"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(name string, expected []string) {
+		t.Helper()
+		if got := treeState(t, db, main); !slices.Equal(expected, got) {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+
+	expect("add", []string{" a", " .b", " ..c", " .d", " e"})
+
+	ids := map[string]int{}
+	db.TodoItems(main, func(t Todo) { ids[t.Task] = t.Id })
+
+	// completing all of the children completes the parents
+	db.TodoDone(ids["c"], true)
+	expect("done c", []string{" a", "x.b", "x..c", " .d", " e"})
+	db.TodoDone(ids["d"], true)
+	expect("done d", []string{"xa", "x.b", "x..c", "x.d", " e"})
+
+	// reopening a child reopens the parents
+	db.TodoDone(ids["c"], false)
+	expect("undone c", []string{" a", " .b", " ..c", "x.d", " e"})
+
+	// moving a parent moves the children
+	db.ChangePosition(ids["e"], 5, 1)
+	expect("move e", []string{" e", " a", " .b", " ..c", "x.d"})
+	db.ChangePosition(ids["d"], 5, 3)
+	expect("move d", []string{" e", " a", "x.d", " .b", " ..c"})
+
+	// sub-items can be added under an existing item
+	db.AddTodoTree(main, ids["e"], []TodoInput{{Task: "f"}, {Task: "g", Level: 1}})
+	expect("add under e", []string{" e", " .f", " ..g", " a", "x.d", " .b", " ..c"})
+
+	if err = db.SetParent(ids["a"], ids["c"]); err == nil {
+		t.Error("expected an error when placing an item under its own sub-item")
+	}
+	db.SetParent(ids["a"], ids["e"])
+	expect("set parent", []string{" e", " .f", " ..g", " .a", "x..d", " ..b", " ...c"})
+	db.SetParent(ids["a"], 0)
+	expect("clear parent", []string{" e", " .f", " ..g", " a", "x.d", " .b", " ..c"})
+
+	// deleting an item moves the sub-items up
+	db.Delete(ids["b"])
+	expect("delete b", []string{" e", " .f", " ..g", " a", "x.d", " .c"})
+
+	// moving an item moves the sub-items as well
+	db.MoveTodo(ids["e"], queue)
+	expect("move to queue", []string{" a", "x.d", " .c"})
+	if got := treeState(t, db, queue); !slices.Equal([]string{" e", " .f", " ..g"}, got) {
+		t.Errorf("queue: got %q", got)
+	}
+
+	if what := db.TodoWhat(main); what == nil || what.Task != "c" {
+		t.Errorf("expected c as the next item, got %v", what)
+	}
+}
+
+func TestSortAsTree(t *testing.T) {
+	parent := func(id int) sql.NullInt64 { return sql.NullInt64{Int64: int64(id), Valid: true} }
+	items := []Todo{
+		{Id: 3, Task: "c", ParentId: parent(1)},
+		{Id: 4, Task: "d", ParentId: parent(9)},
+		{Id: 2, Task: "b", ParentId: parent(3)},
+		{Id: 1, Task: "a"},
+	}
+
+	sorted := SortAsTree(items)
+	levels := ItemLevels(sorted)
+	got := []string{}
+	for _, t := range sorted {
+		got = append(got, strings.Repeat(".", levels[t.Id])+t.Task)
+	}
+
+	expected := []string{"d", "a", ".c", "..b"}
+	if !slices.Equal(expected, got) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestAddTodoTreeImported(t *testing.T) {
+	db, err := NewTodoDbSrc("file:treeimport.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	err = db.AddTodoTree(main, 0, []TodoInput{
+		{Task: "old", CreatedAt: "2026-01-02 00:00:00", DoneAt: "2026-01-05 00:00:00"},
+		{Task: "new"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := []Todo{}
+	db.TodoItems(main, func(t Todo) { items = append(items, t) })
+
+	if items[0].CreatedAt != "2026-01-02 00:00:00" || items[0].DoneAt.String != "2026-01-05 00:00:00" {
+		t.Errorf("wrong dates of the imported item: %v", items[0])
+	}
+	if items[1].CreatedAt == "" || items[1].DoneAt.Valid {
+		t.Errorf("wrong dates of the new item: %v", items[1])
+	}
+}
+
+func TestBulkActions(t *testing.T) {
+	db, err := NewTodoDbSrc("file:bulk.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	queue := db.FetchProjectId("/tmp/repo", "*")
+
+	err = db.AddTodoTree(main, 0, []TodoInput{
+		{Task: "a"},
+		{Task: "b", Level: 1},
+		{Task: "c", Level: 1},
+		{Task: "d"},
+		{Task: "e"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(name string, expected []string) {
+		t.Helper()
+		if got := treeState(t, db, main); !slices.Equal(expected, got) {
+			t.Errorf("%s: expected %q, got %q", name, expected, got)
+		}
+	}
+
+	ids := map[string]int{}
+	db.TodoItems(main, func(t Todo) { ids[t.Task] = t.Id })
+
+	db.TodosDone([]int{ids["b"], ids["c"], ids["e"]}, true)
+	expect("done", []string{"xa", "x.b", "x.c", " d", "xe"})
+	db.TodosDone([]int{ids["c"], ids["e"]}, false)
+	expect("undone", []string{" a", "x.b", " .c", " d", " e"})
+
+	// sub-items are reordered among their siblings
+	db.ReorderTodos(main, []int{ids["e"], ids["c"], ids["d"]})
+	expect("reorder", []string{" e", " d", " a", " .c", "x.b"})
+
+	// moving a parent with its sub-item keeps them together
+	db.MoveTodos([]int{ids["a"], ids["c"], ids["e"]}, queue)
+	expect("move", []string{" d"})
+	if got := treeState(t, db, queue); !slices.Equal([]string{" a", " .c", "x.b", " e"}, got) {
+		t.Errorf("queue: got %q", got)
+	}
+
+	// copies are pending, and sub-items are copied only with their parents
+	feat := db.FetchProjectId("/tmp/repo", "feat")
+	db.AddTodos(feat, []string{"f"})
+	copies, err := db.CopyTodos([]int{ids["a"], ids["b"], ids["d"]}, feat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(copies) != 2 || db.GetTodo(copies[0]).Task != "a" {
+		t.Errorf("unexpected copies: %v", copies)
+	}
+	if got := treeState(t, db, feat); !slices.Equal([]string{" f", " a", " .c", " .b", " d"}, got) {
+		t.Errorf("copy: got %q", got)
+	}
+	expect("copy source", []string{" d"})
+
+	db.DeleteTodos([]int{ids["a"], ids["e"]})
+	if got := treeState(t, db, queue); !slices.Equal([]string{" c", "xb"}, got) {
+		t.Errorf("queue after delete: got %q", got)
+	}
+}
+
+func TestGetBranches(t *testing.T) {
+	db, err := NewTodoDbSrc("file:branches.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	feat := db.FetchProjectId("/tmp/repo", "feat")
+	other := db.FetchProjectId("/tmp/other", "main")
+	db.FetchProjectId("/tmp/empty", "main")
+	db.AddTodos(main, []string{"a", "b", "c"})
+	db.AddTodos(feat, []string{"d"})
+	db.AddTodos(other, []string{"e"})
+	db.TodoDone(db.TodoAtPosition(main, 1).Id, true)
+
+	repos, err := db.GetRepos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(repos, []string{"/tmp/other", "/tmp/repo"}) {
+		t.Errorf("unexpected repos: %v", repos)
+	}
+
+	branches, err := db.GetBranches("/tmp/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []BranchItem{
+		{ProjectId: feat, BranchName: "feat", ItemCount: 1, PendingCount: 1},
+		{ProjectId: main, BranchName: "main", ItemCount: 3, PendingCount: 2, DoneCount: 1},
+	}
+	if !slices.Equal(branches, expected) {
+		t.Errorf("expected %v, got %v", expected, branches)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// NewUUID generates a random (version 4) uuid
+func NewUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ItemUUIDs returns the uuids of the items of the project, which link them
+// to the tasks of other applications. Items without one get a new uuid.
+func (tdb *TodoDb) ItemUUIDs(projId int) (map[int]string, error) {
+	tx, err := tdb.db.Beginx()
+
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	missing := []int{}
+	err = tx.Select(&missing, `select todo_id from todo 
+	where project_id = $1 and todo_id not in (select todo_id from todo_uuid)`, projId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range missing {
+		if _, err = tx.Exec("insert into todo_uuid (uuid, todo_id) values ($1, $2)", NewUUID(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Queryx(`select u.todo_id, u.uuid from todo_uuid u 
+	join todo t on t.todo_id = u.todo_id where t.project_id = $1`, projId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	uuids, err := scanUUIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]string, len(uuids))
+	for uuid, id := range uuids {
+		result[id] = uuid
+	}
+
+	return result, tx.Commit()
+}
+
+// FindUUIDs returns the ids of the items linked to the given uuids,
+// skipping the uuids that are not linked to any of the items
+func (tdb *TodoDb) FindUUIDs(uuids []string) (map[string]int, error) {
+	if len(uuids) == 0 {
+		return map[string]int{}, nil
+	}
+
+	query, args, err := sqlx.In("select todo_id, uuid from todo_uuid where uuid in (?)", uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tdb.db.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanUUIDs(rows)
+}
+
+func scanUUIDs(rows *sqlx.Rows) (map[string]int, error) {
+	defer rows.Close()
+	result := map[string]int{}
+
+	for rows.Next() {
+		var id int
+		var uuid string
+		if err := rows.Scan(&id, &uuid); err != nil {
+			return nil, err
+		}
+		result[uuid] = id
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestItemUUIDs(t *testing.T) {
+	db, err := NewTodoDbSrc("file:uuid.db?mode=memory&_fk=true")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	defer db.Close()
+
+	main := db.FetchProjectId("/tmp/repo", "main")
+	err = db.AddTodoTree(main, 0, []TodoInput{
+		{Task: "imported", UUID: "c7e1f3a2-0000-4000-8000-000000000001"},
+		{Task: "local"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuids, err := db.ItemUUIDs(main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(uuids) != 2 || uuids[1] != "c7e1f3a2-0000-4000-8000-000000000001" {
+		t.Fatalf("unexpected uuids: %v", uuids)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(uuids[2]) {
+		t.Errorf("invalid uuid %q", uuids[2])
+	}
+
+	again, _ := db.ItemUUIDs(main)
+	if again[2] != uuids[2] {
+		t.Errorf("uuid changed from %q to %q", uuids[2], again[2])
+	}
+
+	found, err := db.FindUUIDs([]string{uuids[2], "unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[uuids[2]] != 2 {
+		t.Errorf("unexpected items: %v", found)
+	}
+
+	db.Delete(2)
+	if found, _ = db.FindUUIDs([]string{uuids[2]}); len(found) != 0 {
+		t.Errorf("uuid of the deleted item was kept: %v", found)
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/drazengolic/gitodo/base"
 	"github.com/drazengolic/gitodo/shell"
 	"github.com/spf13/cobra"
 )
@@ -35,8 +37,22 @@ Invoking without arguments will open up the editor for multiple items to be
 added. If there are arguments, all of them will be joined into a single to-do
 item.
 
+In the editor, items can be nested by indenting the hyphens with two spaces
+per level, i.e. "  - sub-item" under the item above it.
+
 If the flag -t is provided, the new item will be placed at the top of the 
-list.`,
+list.
+
+If the flag -p is provided, the new items will be added as sub-items of the
+given item.
+
+If the --template flag is provided, the items of the template with the given
+name are added instead, see "gitodo help template".
+` + itemRefHelp + `
+
+` + dueHelp + `
+
+Set the --json flag to print the added items as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
@@ -44,26 +60,68 @@ list.`,
 		_, err := tdb.CheckTimer(projId)
 		HandleTimerError(err)
 
+		parentId := 0
+		if ref, _ := cmd.Flags().GetString("parent"); ref != "" {
+			parent := mustFindItem(tdb, env, ref)
+			if parent.ProjectId != projId {
+				ExitOnError(errors.New("The parent item must be in the current branch."), 1)
+			}
+			parentId = parent.Id
+		}
+
+		template, _ := cmd.Flags().GetString("template")
+		if template != "" && len(args) > 0 {
+			ExitOnError(errors.New("Items can't be given as arguments together with a template."), 1)
+		}
+
 		if len(args) > 0 {
 			item := strings.Join(args, " ")
-			id, pos := tdb.AddTodo(projId, item)
+			id, pos := addItem(tdb, projId, item)
 			if cmd.Flags().Changed("top") {
 				tdb.ChangePosition(id, pos, 1)
 			}
-			fmt.Printf("Added to-do item %q to %q\n", item, env.Branch)
+			if parentId != 0 {
+				err = tdb.SetParent(id, parentId)
+				ExitOnError(err, 1)
+			}
+			if jsonMode {
+				printAddedJSON(tdb, projId, []base.Todo{*tdb.GetTodo(id)})
+				return
+			}
+			fmt.Printf("Added to-do item %q to %q\n", tdb.GetTodo(id).Task, env.Branch)
 		} else {
-			tmpfile, err := shell.NewItemsTmpFile()
-			ExitOnError(err, 1)
-			err = tmpfile.Edit(env.Editor, 3)
-			ExitOnError(err, 1)
-			items, err := tmpfile.ReadItems()
+			var items []shell.Item
+
+			if template != "" {
+				items, err = templateItems(mustFindTemplate(env, tdb, template))
+			} else {
+				var tmpfile *shell.TmpFile
+				tmpfile, err = shell.NewItemsTmpFile()
+				ExitOnError(err, 1)
+				err = tmpfile.Edit(env.Editor, 3)
+				ExitOnError(err, 1)
+				items, err = tmpfile.ReadItems()
 
-			tmpfile.Delete()
+				tmpfile.Delete()
+			}
 
 			ExitOnError(err, 1)
-			err = tdb.AddTodos(projId, items)
+			existing := map[int]struct{}{}
+			tdb.TodoItems(projId, func(t base.Todo) { existing[t.Id] = struct{}{} })
+			err = tdb.AddTodoTree(projId, parentId, todoInputs(items))
 			ExitOnError(err, 1)
 
+			if jsonMode {
+				added := []base.Todo{}
+				tdb.TodoItems(projId, func(t base.Todo) {
+					if _, ok := existing[t.Id]; !ok {
+						added = append(added, t)
+					}
+				})
+				printAddedJSON(tdb, projId, added)
+				return
+			}
+
 			fmt.Printf("Added %d item(s) to %q\n", len(items), env.Branch)
 		}
 	},
@@ -72,4 +130,15 @@ list.`,
 func init() {
 	RootCmd.AddCommand(addCmd)
 	addCmd.Flags().BoolP("top", "t", false, "put the item at the top of the list")
+	addCmd.Flags().StringP("parent", "p", "", "add the items as sub-items of the given item")
+	addCmd.Flags().StringP("template", "T", "", "add the items of the template")
+	addCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+func printAddedJSON(tdb *base.TodoDb, projId int, items []base.Todo) {
+	proj := tdb.GetProject(projId)
+	printJSON(struct {
+		Project *base.Project `json:"project"`
+		Added   []base.Todo   `json:"added"`
+	}{Project: &proj, Added: items})
 }
@@ -37,34 +37,74 @@ Display to-do items as a changelist usable in markdown documents.
 By default, it displays only the completed items. If --all flag is set, all
 items will be displayed in the form of a GitHub task list.
 
-If using a pager is desirable, set the --pager flag.`,
+Sub-items are nested under their parents, as long as the parents are listed.
+
+Only the titles of the items are displayed, unless the --with-notes flag is
+set, in which case the notes are displayed under the items.
+
+If using a pager is desirable, set the --pager flag.
+
+Set the --json flag to print the items as a JSON object. The pager is not used
+in that case.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		all := cmd.Flags().Changed("all")
+		withNotes := cmd.Flags().Changed("with-notes")
 		noPager := !cmd.Flags().Changed("pager")
 		builder := strings.Builder{}
 		count := 0
 
+		if jsonMode {
+			proj := tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch))
+			items := []base.Todo{}
+			collect := func(t base.Todo) { items = append(items, t) }
+			if all {
+				err := tdb.TodoItems(proj.Id, collect)
+				ExitOnError(err, 1)
+			} else {
+				err := tdb.TodoItemsDone(proj.Id, collect)
+				ExitOnError(err, 1)
+			}
+			printJSON(struct {
+				Project *base.Project `json:"project"`
+				Items   []base.Todo   `json:"items"`
+			}{Project: &proj, Items: items})
+			return
+		}
+
+		items := []base.Todo{}
+		collect := func(t base.Todo) { items = append(items, t) }
+
 		if all {
-			tdb.TodoItems(tdb.FetchProjectId(env.ProjDir, env.Branch), func(t base.Todo) {
-				count++
-
-				if t.DoneAt.Valid {
-					builder.WriteString("- [x] ")
-				} else {
-					builder.WriteString("- [ ] ")
-				}
-
-				builder.WriteString(t.Task)
-				builder.WriteRune('\n')
-			})
+			tdb.TodoItems(tdb.FetchProjectId(env.ProjDir, env.Branch), collect)
 		} else {
-			tdb.TodoItemsDone(tdb.FetchProjectId(env.ProjDir, env.Branch), func(t base.Todo) {
-				count++
+			tdb.TodoItemsDone(tdb.FetchProjectId(env.ProjDir, env.Branch), collect)
+		}
+
+		// sub-items are nested under their parents
+		items = base.SortAsTree(items)
+		levels := base.ItemLevels(items)
+
+		for _, t := range items {
+			count++
+			indent := strings.Repeat("  ", levels[t.Id])
+			builder.WriteString(indent)
+
+			switch {
+			case !all:
 				builder.WriteString("- ")
-				builder.WriteString(t.Task)
-				builder.WriteRune('\n')
-			})
+			case t.DoneAt.Valid:
+				builder.WriteString("- [x] ")
+			default:
+				builder.WriteString("- [ ] ")
+			}
+
+			builder.WriteString(t.Task)
+			builder.WriteRune('\n')
+
+			if withNotes {
+				writeNotes(&builder, t.Notes, indent+"  ")
+			}
 		}
 
 		if count == 0 {
@@ -98,4 +138,23 @@ func init() {
 
 	changelistCmd.Flags().BoolP("all", "a", false, "show all")
 	changelistCmd.Flags().BoolP("pager", "p", false, "use PAGER for output")
+	changelistCmd.Flags().BoolP("with-notes", "n", false, "show the notes of the items")
+	changelistCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+// writeNotes writes the notes of an item as an indented markdown paragraph
+func writeNotes(builder *strings.Builder, notes, indent string) {
+	if notes == "" {
+		return
+	}
+
+	builder.WriteRune('\n')
+	for _, line := range strings.Split(notes, "\n") {
+		if strings.TrimSpace(line) != "" {
+			builder.WriteString(indent)
+			builder.WriteString(line)
+		}
+		builder.WriteRune('\n')
+	}
+	builder.WriteRune('\n')
 }
@@ -19,6 +19,7 @@ package cmd
 import (
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/drazengolic/gitodo/base"
@@ -36,7 +37,8 @@ Run "git commit" with a prepared message based on the completed to-do items
 that will also be marked as committed if the commit was successful.
 
 Items that are previously marked as committed will not be included in the 
-message unless "--amend" flag is provided.
+message unless "--amend" flag is provided. Only the titles of the items are
+included in the message by default.
 
 By default, the command will execute "git commit -eF msgfile", and any 
 additional arguments or flags passed to this command will be appended to the
@@ -50,10 +52,18 @@ Special flag handling:
 
  - if "--no-edit" is passed together with "--amend", no message will be 
    generated and "-eF" will be left out
+
+ - if "--with-notes" is passed, the notes of the items will be included in the
+   message as well, and the flag will not be passed to git
+
+If the branch has a ticket id (see "gitodo help name"), the first line of the
+message will start with it. The format of the prefix can be set with the
+gitodo.commitTicket git config key, where {ticket} is replaced with the ticket
+id, and it defaults to "{ticket}: ".
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
-		amend, noEdit := false, false
+		amend, noEdit, withNotes := false, false, false
 
 		for _, arg := range args {
 			switch {
@@ -61,9 +71,13 @@ Special flag handling:
 				amend = true
 			case arg == "--no-edit":
 				noEdit = true
+			case arg == "--with-notes":
+				withNotes = true
 			}
 		}
 
+		args = slices.DeleteFunc(args, func(arg string) bool { return arg == "--with-notes" })
+
 		proj := tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch))
 
 		_, err := tdb.CheckTimer(proj.Id)
@@ -85,10 +99,23 @@ Special flag handling:
 			builder.WriteString("\n\n")
 		}
 
+		// the ticket prefixes the first line of the message
+		if proj.Ticket != "" {
+			format, ok := shell.GitConfig("gitodo.commitTicket")
+			if !ok {
+				format = "{ticket}: "
+			}
+			builder.WriteString(strings.ReplaceAll(format, "{ticket}", proj.Ticket))
+		}
+
 		tdb.TodoItemsForCommit(proj.Id, amend, func(t base.Todo) {
 			builder.WriteString("- ")
 			builder.WriteString(t.Task)
 			builder.WriteRune('\n')
+
+			if withNotes {
+				writeNotes(&builder, t.Notes, "  ")
+			}
 		})
 
 		file, err := shell.NewTmpFileString(builder.String())
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/ui"
+	"github.com/spf13/cobra"
+)
+
+// dashboardCmd represents the dashboard command
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "View all repositories and branches",
+	Long: `
+View every repository and branch that has items, with the number of pending
+and completed items, the total tracked time, the latest activity, and the
+number of stashes of the items.
+
+The command can be executed anywhere, it is not required to be within a git
+repository. The branch that is checked out in its repository is marked with
+an asterisk.
+
+Press enter to view the items of the selected branch, and "c" to check out the
+branch in its repository. The working directory of the shell that started the
+dashboard is not changed.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tdb, err := base.NewTodoDb()
+		ExitOnError(err, 1)
+
+		err = ui.RunDashboard(tdb)
+		ExitOnError(err, 1)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(dashboardCmd)
+}
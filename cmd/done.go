@@ -25,16 +25,22 @@ import (
 
 // doneCmd represents the done command
 var doneCmd = &cobra.Command{
-	Use:   "done",
+	Use:   "done [item]",
 	Short: "Set the first available to-do item to done",
 	Long: `
 Set the first available to-do item to done and output the next to-do item if
 found.
 
+If an item is given as the argument, that item is set to done instead.
+` + itemRefHelp + `
+
 If there are no items to be done, the "All done!" message is shown.
 
 If there is a timer running, it will display the session time at the moment of
-the command execution.`,
+the command execution.
+
+Set the --json flag to print the completed item, the next item and the timer
+state as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
@@ -44,6 +50,31 @@ the command execution.`,
 
 		item := tdb.TodoWhat(projId)
 
+		if len(args) > 0 {
+			item = mustFindItem(tdb, env, args[0])
+			if item.DoneAt.Valid {
+				ExitOnError(fmt.Errorf("Item #%d is already done.", item.Id), 1)
+			}
+		}
+
+		if jsonMode {
+			if item != nil {
+				err = tdb.TodoDone(item.Id, true)
+				ExitOnError(err, 1)
+				item = tdb.GetTodo(item.Id)
+			}
+			printJSON(struct {
+				Done  *base.Todo `json:"done"`
+				Next  *base.Todo `json:"next"`
+				Timer jsonTimer  `json:"timer"`
+			}{
+				Done:  item,
+				Next:  tdb.TodoWhat(projId),
+				Timer: timerState(tdb, te, projId),
+			})
+			return
+		}
+
 		if item == nil {
 			fmt.Println(greenTextStyle.Render("All done!"))
 			return
@@ -69,4 +100,5 @@ the command execution.`,
 
 func init() {
 	RootCmd.AddCommand(doneCmd)
+	doneCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
 }
@@ -0,0 +1,197 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit [item [text...]]",
+	Short: "Edit to-do items",
+	Long: `
+Edit the text of a to-do item, or the whole to-do list of the current branch.
+
+Invoking without arguments will open up the editor with the list of the current
+branch formatted as a markdown task list, where the items can be reordered,
+completed, reopened, rewritten, deleted or added. Sub-items are indented by two
+spaces under their parents, and changing the indentation moves them under
+another item or to the top level. A summary of the changes is printed before
+they are applied, and a confirmation is asked unless the --yes flag is set.
+Completed items can't be deleted, and they will be kept at the bottom of the
+list if their lines are removed.
+
+If an item is given as the argument, only that item will be opened in the
+editor, where the first line is the title of the item, and the rest are the
+notes. If there are arguments after the item, all of them will be joined into
+the new title of the item, and the notes will be left as they are.
+` + itemRefHelp + `
+
+` + dueHelp + `
+
+Set the --json flag to print the item as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		if len(args) == 0 {
+			editList(env, tdb, projId, cmd.Flags().Changed("yes"))
+			return
+		}
+
+		item := mustFindItem(tdb, env, args[0])
+		var txt string
+
+		if len(args) > 1 {
+			txt = strings.Join(args[1:], " ")
+		} else {
+			tmp, err := shell.NewTmpFileString(itemTokens(item))
+			ExitOnError(err, 1)
+			err = tmp.Edit(env.Editor, 0)
+			txt = tmp.ReadAll()
+			tmp.Delete()
+			ExitOnError(err, 1)
+		}
+
+		txt, due, hasDue := shell.ExtractDue(strings.TrimSpace(txt), time.Now())
+		txt, estimate, hasEstimate := shell.ExtractEstimate(txt)
+		if txt == "" {
+			ExitOnError(errors.New("The text of the item can't be empty."), 1)
+		}
+
+		if len(args) > 1 {
+			err = tdb.UpdateTask(item.Id, txt)
+		} else {
+			err = tdb.UpdateItemText(item.Id, txt)
+		}
+		ExitOnError(err, 1)
+
+		// the editor shows the tokens, so removing them clears the values
+		if hasDue || len(args) == 1 {
+			err = tdb.SetDue(item.Id, due)
+			ExitOnError(err, 1)
+		}
+		if hasEstimate || len(args) == 1 {
+			err = tdb.SetEstimate(item.Id, estimate)
+			ExitOnError(err, 1)
+		}
+
+		if jsonMode {
+			printItemJSON(tdb.GetTodo(item.Id))
+			return
+		}
+
+		fmt.Printf("Updated item #%d\n", item.Id)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(editCmd)
+	editCmd.Flags().BoolP("yes", "y", false, "Apply changes to the list without asking")
+	editCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+// editList edits the whole list of the project as a markdown document
+func editList(env *shell.DirEnv, tdb *base.TodoDb, projId int, yes bool) {
+	proj := tdb.GetProject(projId)
+	current := []base.Todo{}
+	listItems := []shell.TaskListItem{}
+
+	// items come in the order of the tree, after their parents
+	levels := map[int64]int{}
+	err := tdb.TodoItems(projId, func(t base.Todo) {
+		level := 0
+		if l, ok := levels[t.ParentId.Int64]; ok && t.ParentId.Valid {
+			level = l + 1
+		}
+		levels[int64(t.Id)] = level
+
+		current = append(current, t)
+		listItems = append(listItems, shell.TaskListItem{Id: t.Id, Done: t.DoneAt.Valid, Task: itemTokens(&t), Level: level})
+	})
+	ExitOnError(err, 1)
+
+	tmp, err := shell.NewTaskListTmpFile(fmt.Sprintf("To-do list of %q", proj.Name), listItems)
+	ExitOnError(err, 1)
+	err = tmp.Edit(env.Editor, 0)
+	if err != nil {
+		tmp.Delete()
+		ExitOnError(err, 1)
+	}
+	edited, err := tmp.ReadTaskList()
+	tmp.Delete()
+	ExitOnError(err, 1)
+
+	now := time.Now()
+	items := make([]base.ListItem, len(edited))
+	for i, e := range edited {
+		task, due, _ := shell.ExtractDue(e.Task, now)
+		task, estimate, _ := shell.ExtractEstimate(task)
+		items[i] = base.ListItem{Id: e.Id, Done: e.Done, Task: task, Due: due, Estimate: estimate, Level: e.Level}
+	}
+
+	plan := base.PlanListChanges(current, items)
+
+	if !plan.HasChanges() {
+		fmt.Println("No changes.")
+		return
+	}
+
+	summary := []string{}
+	for _, c := range []struct {
+		count int
+		what  string
+	}{
+		{plan.Added, "added"},
+		{plan.Updated, "rewritten"},
+		{plan.Completed, "completed"},
+		{plan.Reopened, "reopened"},
+		{plan.Reordered, "moved"},
+		{len(plan.Deleted), "deleted"},
+	} {
+		if c.count > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", c.count, c.what))
+		}
+	}
+
+	fmt.Printf("Changes: %s\n", strings.Join(summary, ", "))
+	for _, t := range plan.Deleted {
+		fmt.Println(redText.Render("  - " + t.Task))
+	}
+	if len(plan.Kept) > 0 {
+		fmt.Println(orangeText.Render(fmt.Sprintf("%d completed item(s) can't be deleted and will be kept.", len(plan.Kept))))
+	}
+
+	if !yes && !askYesNo("Apply the changes?") {
+		return
+	}
+
+	err = tdb.ApplyListPlan(projId, plan)
+	ExitOnError(err, 1)
+	fmt.Println("ok")
+}
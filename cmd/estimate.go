@@ -0,0 +1,106 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// estimateCmd represents the estimate command
+var estimateCmd = &cobra.Command{
+	Use:   "estimate [[item] duration]",
+	Short: "Display or set estimates",
+	Args:  cobra.MaximumNArgs(2),
+	Long: `
+Display or set the estimates of the current branch and its items.
+
+When no argument is given, the command will output the estimate of the branch,
+the time tracked so far, and the remaining estimate. The estimate of the branch
+is the one set for it, or the sum of the estimates of its items if not set.
+The remaining estimate is the sum of the estimates of the items that are not
+done, or the estimate of the branch minus the tracked time if no items are
+estimated.
+
+With one argument, the estimate of the branch is set to the given duration.
+With two arguments, the estimate of the given item is set instead. Durations
+are given in hours and minutes (i.e. 2h, 45m or 1h30m), or as a number of
+hours, and "none" removes the estimate. Story points are not supported, since
+they can't be compared with the tracked time.
+` + itemRefHelp + `
+
+Set the --json flag to print the estimates as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		if len(args) > 0 {
+			_, err := tdb.CheckTimer(projId)
+			HandleTimerError(err)
+
+			seconds, ok := shell.ParseEstimate(args[len(args)-1])
+			if !ok {
+				ExitOnError(fmt.Errorf("Invalid duration %q.", args[len(args)-1]), 1)
+			}
+
+			if len(args) == 2 {
+				err = tdb.SetEstimate(mustFindItem(tdb, env, args[0]).Id, seconds)
+			} else {
+				err = tdb.SetProjectEstimate(projId, seconds)
+			}
+			ExitOnError(err, 1)
+		}
+
+		es, err := tdb.GetEstimateSummary(projId)
+		ExitOnError(err, 1)
+
+		if jsonMode {
+			proj := tdb.GetProject(projId)
+			printJSON(struct {
+				Project  *base.Project         `json:"project"`
+				Estimate *base.EstimateSummary `json:"estimate"`
+			}{&proj, es})
+			return
+		}
+
+		if es.TotalSec() == 0 {
+			fmt.Println("No estimates.")
+			return
+		}
+
+		fmt.Printf("Estimate: %s\n", base.FormatEstimate(es.TotalSec()))
+		if es.ProjectSec > 0 && es.ItemsSec > 0 {
+			fmt.Println(dimmedText.Render("Items: " + base.FormatEstimate(es.ItemsSec)))
+		}
+		fmt.Printf("Tracked: %s\n", base.FormatEstimate(es.ElapsedSec))
+
+		remaining := fmt.Sprintf("Remaining: %s", base.FormatEstimate(es.RemainingSec))
+		if es.ElapsedSec > es.TotalSec() {
+			fmt.Println(redText.Render(remaining + " (over the estimate)"))
+		} else {
+			fmt.Println(remaining)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(estimateCmd)
+	estimateCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"golang.org/x/term"
+)
+
+// itemRefHelp describes the item reference syntax in command docs
+const itemRefHelp = `Items are referenced by their id as printed by the "list" command (i.e. 12
+or #12), or by their position in the current branch prefixed with @ (i.e. @1
+for the top item). Only items of the current repository can be referenced.`
+
+// dueHelp describes the due date syntax in command docs
+const dueHelp = `Due dates are set by adding a due:date token to the title of an item, where
+the date is either YYYY-MM-DD, today, tomorrow or a day of the week (i.e.
+due:fri for the first Friday starting from today). Use due:none to remove it.
+
+Estimates are set by adding an est:duration token to the title of an item,
+where the duration is given in hours and minutes (i.e. est:2h, est:45m or
+est:1h30m). Use est:none to remove it.`
+
+// parseItemRef parses the item reference into either an id or a position
+func parseItemRef(ref string) (id, position int, err error) {
+	if p, ok := strings.CutPrefix(ref, "@"); ok {
+		position, err = strconv.Atoi(p)
+		if err != nil || position < 1 {
+			return 0, 0, fmt.Errorf("Invalid position %q.", ref)
+		}
+		return 0, position, nil
+	}
+
+	id, err = strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || id < 1 {
+		return 0, 0, fmt.Errorf("Invalid item id %q.", ref)
+	}
+	return id, 0, nil
+}
+
+// mustFindItem finds the item by the reference, or exits if not found
+func mustFindItem(tdb *base.TodoDb, env *shell.DirEnv, ref string) *base.Todo {
+	id, position, err := parseItemRef(ref)
+	ExitOnError(err, 1)
+
+	if position > 0 {
+		item := tdb.TodoAtPosition(tdb.FetchProjectId(env.ProjDir, env.Branch), position)
+		if item == nil {
+			ExitOnError(fmt.Errorf("No item at position %d in %q.", position, env.Branch), 1)
+		}
+		return item
+	}
+
+	item := tdb.GetTodo(id)
+	if item == nil || tdb.GetProject(item.ProjectId).Folder != env.ProjDir {
+		ExitOnError(fmt.Errorf("Item #%d not found in this repository.", id), 1)
+	}
+	return item
+}
+
+// askYesNo prints the question and reads a single key as the answer.
+// It exits if the key can't be read, i.e. when the input is not a terminal.
+func askYesNo(question string) bool {
+	key := make([]byte, 1)
+	fmt.Printf("%s (y/n) ", question)
+
+	// set up single key reading
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		fmt.Println("")
+		ExitOnError(fmt.Errorf("Can't read the answer (%v), use the --yes flag to confirm.", err), 1)
+	}
+	os.Stdin.Read(key)
+	term.Restore(int(os.Stdin.Fd()), oldState)
+	fmt.Println("")
+
+	return key[0] == 'y' || key[0] == 'Y'
+}
+
+func printItemJSON(item *base.Todo) {
+	printJSON(map[string]*base.Todo{"item": item})
+}
+
+// todoInputs converts the items read from the editor into new to-do items
+func todoInputs(items []shell.Item) []base.TodoInput {
+	inputs := make([]base.TodoInput, len(items))
+	for i, item := range items {
+		inputs[i] = base.TodoInput{Task: item.Task, Level: item.Level, Due: item.Due, Estimate: item.Estimate}
+	}
+	return inputs
+}
+
+// addItem adds an item from the text given as arguments, with the due date
+// and the estimate if the text contains the due:date or est:duration tokens
+func addItem(tdb *base.TodoDb, projId int, text string) (int, int) {
+	text, due, _ := shell.ExtractDue(text, time.Now())
+	text, estimate, _ := shell.ExtractEstimate(text)
+	id, pos := tdb.AddTodo(projId, text)
+	if due != "" {
+		tdb.SetDue(id, due)
+	}
+	if estimate > 0 {
+		tdb.SetEstimate(id, estimate)
+	}
+	return id, pos
+}
+
+// itemTokens appends the due:date and est:duration tokens of the item
+// to its text, as it's edited in the editor
+func itemTokens(t *base.Todo) string {
+	return shell.AppendEstimate(shell.AppendDue(t.Text(), t.DueAt.String), int(t.Estimate.Int64))
+}
+
+// estimateLabel renders the estimate of the item,
+// or an empty string if not set
+func estimateLabel(t *base.Todo) string {
+	if !t.Estimate.Valid {
+		return ""
+	}
+	return dimmedText.Render(" • est " + base.FormatEstimate(int(t.Estimate.Int64)))
+}
+
+// dueLabel renders the due date of the item, highlighted if
+// it's due today or overdue, or an empty string if not set
+func dueLabel(t *base.Todo, today string) string {
+	switch {
+	case !t.DueAt.Valid:
+		return ""
+	case t.IsOverdue(today):
+		return redText.Render(" • overdue since " + t.DueAt.String)
+	case t.DueAt.String == today && !t.DoneAt.Valid:
+		return orangeText.Render(" • due today")
+	default:
+		return dimmedText.Render(" • due " + t.DueAt.String)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/drazengolic/gitodo/base"
+)
+
+// exit codes, where the timer ones are used only with the --json flag
+const (
+	ExitCodeError          = 1
+	ExitCodeTimer          = 2
+	ExitCodeTimerElsewhere = 3
+)
+
+// jsonMode is set when the executed command has the --json flag set,
+// so that both the results and the errors are printed as JSON
+var jsonMode bool
+
+type jsonError struct {
+	Type        string        `json:"type"`
+	Message     string        `json:"message"`
+	ExitCode    int           `json:"exit_code"`
+	Project     *base.Project `json:"project,omitempty"`
+	DurationSec int           `json:"duration_sec,omitempty"`
+}
+
+type jsonTimer struct {
+	Running     bool   `json:"running"`
+	StartedAt   string `json:"started_at,omitempty"`
+	DurationSec int    `json:"duration_sec"`
+	TotalSec    int    `json:"total_sec"`
+}
+
+func printJSON(v any) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	ExitOnError(err, 1)
+	fmt.Printf("%s\n", b)
+}
+
+func printJSONError(e jsonError) {
+	b, _ := json.MarshalIndent(map[string]jsonError{"error": e}, "", "  ")
+	fmt.Printf("%s\n", b)
+}
+
+// timerState creates the timer state of the project from the latest entry
+func timerState(tdb *base.TodoDb, te *base.TimeEntry, projId int) jsonTimer {
+	state := jsonTimer{}
+	state.TotalSec, _ = tdb.GetProjectTime(projId)
+
+	if te != nil && te.ProjectId == projId && te.Action == base.TimesheetActionStart {
+		state.Running = true
+		state.StartedAt = base.FormatUTC(te.CreatedAt)
+		state.DurationSec = te.Duration()
+	}
+
+	return state
+}
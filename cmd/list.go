@@ -0,0 +1,267 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:     "list [text]",
+	Aliases: []string{"ls"},
+	Short:   "Print to-do items",
+	Long: `
+Print to-do items of the current branch without opening the TUI screen.
+
+Every item is printed with its id that can be used with other commands.
+
+By default, items of the current branch are printed. Set the --queue flag to
+print the queue instead, the --branches flag to print items of all branches in
+the repository, or the --repos flag to print items of all repositories. The
+last one can be executed outside of a git repository.
+
+Items can be filtered with the following flags:
+
+ --pending      items that are not done
+ --done         items that are done
+ --uncommitted  items that are done but not committed
+ --since        items created or done since the given date (YYYY-MM-DD) or
+                time (RFC3339)
+ --due          items due by the given date (YYYY-MM-DD, today, fri etc.)
+
+If more than one of --pending, --done and --uncommitted is set, items matching
+any of them are printed. If there are arguments, they are joined into a text
+that items must contain.
+
+Output format is set with the --format flag, and it can be one of "plain"
+(default), "markdown" or "json". Setting the --json flag is the same as
+setting the format to "json".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		if jsonMode {
+			format = "json"
+		}
+		if format != "plain" && format != "markdown" && format != "json" {
+			ExitOnError(fmt.Errorf("Unknown format %q.", format), 1)
+		}
+		jsonMode = format == "json"
+
+		filter := base.TodoFilter{
+			Pending:     cmd.Flags().Changed("pending"),
+			Done:        cmd.Flags().Changed("done"),
+			Uncommitted: cmd.Flags().Changed("uncommitted"),
+			Text:        strings.Join(args, " "),
+		}
+
+		if cmd.Flags().Changed("since") {
+			since, _ := cmd.Flags().GetString("since")
+			t, err := parseSince(since)
+			ExitOnError(err, 1)
+			filter.Since = t.Format(time.DateTime)
+		}
+
+		if cmd.Flags().Changed("due") {
+			due, _ := cmd.Flags().GetString("due")
+			_, dueBy, ok := shell.ExtractDue("due:"+due, time.Now())
+			if !ok || dueBy == "" {
+				ExitOnError(fmt.Errorf("Could not parse %q as a due date.", due), 1)
+			}
+			filter.DueBy = dueBy
+		}
+
+		var tdb *base.TodoDb
+		var err error
+		allRepos := cmd.Flags().Changed("repos")
+
+		if allRepos {
+			tdb, err = base.NewTodoDb()
+			ExitOnError(err, 1)
+		} else {
+			var env *shell.DirEnv
+			env, tdb = MustInit()
+			filter.Folder = env.ProjDir
+			switch {
+			case cmd.Flags().Changed("branches"):
+			case cmd.Flags().Changed("queue"):
+				filter.Branch = "*"
+			default:
+				filter.Branch = env.Branch
+			}
+		}
+
+		groups := []*listGroup{}
+		err = tdb.FilterTodos(filter, func(p base.Project, t base.Todo) {
+			if len(groups) == 0 || groups[len(groups)-1].Project.Id != p.Id {
+				groups = append(groups, &listGroup{Project: p, Items: []base.Todo{}})
+			}
+			g := groups[len(groups)-1]
+			g.Items = append(g.Items, t)
+		})
+		ExitOnError(err, 1)
+
+		switch format {
+		case "json":
+			printJSON(map[string][]*listGroup{"projects": groups})
+		case "markdown":
+			fmt.Print(listMarkdown(groups, filter.Branch == "", allRepos))
+		default:
+			fmt.Print(listPlain(groups, filter.Branch == "", allRepos))
+		}
+	},
+}
+
+type listGroup struct {
+	Project base.Project `json:"project"`
+	Items   []base.Todo  `json:"items"`
+}
+
+func init() {
+	RootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().BoolP("queue", "q", false, "Print the queue")
+	listCmd.Flags().BoolP("branches", "b", false, "Print items of all branches in the repository")
+	listCmd.Flags().BoolP("repos", "r", false, "Print items of all repositories")
+	listCmd.Flags().Bool("pending", false, "Print items that are not done")
+	listCmd.Flags().Bool("done", false, "Print items that are done")
+	listCmd.Flags().Bool("uncommitted", false, "Print items that are done but not committed")
+	listCmd.Flags().StringP("since", "s", "", "Print items created or done since the date or time")
+	listCmd.Flags().String("due", "", "Print items due by the date")
+	listCmd.Flags().StringP("format", "f", "plain", "Output format: plain, markdown or json")
+	listCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+// parseSince parses the date or RFC3339 time into the local time
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, fmt.Errorf("Could not parse %q as a date (YYYY-MM-DD) or RFC3339 time string.", s)
+	}
+
+	return t.Local(), nil
+}
+
+func listGroupTitle(p *base.Project) string {
+	switch {
+	case p.Branch == "*":
+		return "queue"
+	case p.Name != p.Branch:
+		return fmt.Sprintf("%s (%s)", p.Name, p.Branch)
+	default:
+		return p.Branch
+	}
+}
+
+func listPlain(groups []*listGroup, showProjects, showRepos bool) string {
+	builder := strings.Builder{}
+	folder := ""
+	today := time.Now().Format(time.DateOnly)
+
+	for i, g := range groups {
+		if showRepos && g.Project.Folder != folder {
+			if i > 0 {
+				builder.WriteRune('\n')
+			}
+			builder.WriteString(magentaText.Render(g.Project.Folder))
+			builder.WriteRune('\n')
+			folder = g.Project.Folder
+		}
+
+		if showProjects {
+			builder.WriteString(blueText.Render(listGroupTitle(&g.Project)))
+			builder.WriteRune('\n')
+		}
+
+		levels := base.ItemLevels(g.Items)
+
+		for _, t := range g.Items {
+			id := fmt.Sprintf("%4d", t.Id)
+			indent := strings.Repeat("  ", levels[t.Id])
+			check := "[ ]"
+			if t.DoneAt.Valid {
+				check = "[" + greenTextStyle.Render("x") + "]"
+			}
+			task := strings.ReplaceAll(t.Task, "\n", "\n         "+indent)
+			if t.CommittedAt.Valid {
+				task += dimmedText.Render(" • committed")
+			}
+			task += dueLabel(&t, today) + estimateLabel(&t)
+			if t.Source.Valid {
+				task += dimmedText.Render(" • " + t.Source.String)
+			}
+			builder.WriteString(fmt.Sprintf("%s %s%s %s\n", dimmedText.Render(id), indent, check, task))
+		}
+
+		if showProjects && i < len(groups)-1 {
+			builder.WriteRune('\n')
+		}
+	}
+
+	return builder.String()
+}
+
+func listMarkdown(groups []*listGroup, showProjects, showRepos bool) string {
+	builder := strings.Builder{}
+	folder := ""
+
+	for _, g := range groups {
+		if showRepos && g.Project.Folder != folder {
+			builder.WriteString(fmt.Sprintf("# %s\n\n", g.Project.Folder))
+			folder = g.Project.Folder
+		}
+
+		if showProjects {
+			builder.WriteString(fmt.Sprintf("## %s\n\n", listGroupTitle(&g.Project)))
+		}
+
+		levels := base.ItemLevels(g.Items)
+
+		for _, t := range g.Items {
+			indent := strings.Repeat("  ", levels[t.Id])
+			builder.WriteString(indent)
+			if t.DoneAt.Valid {
+				builder.WriteString("- [x] ")
+			} else {
+				builder.WriteString("- [ ] ")
+			}
+			builder.WriteString(strings.ReplaceAll(t.Task, "\n", "\n  "+indent))
+			if t.DueAt.Valid {
+				builder.WriteString(" due:" + t.DueAt.String)
+			}
+			if t.Estimate.Valid {
+				builder.WriteString(" est:" + base.FormatEstimate(int(t.Estimate.Int64)))
+			}
+			builder.WriteString(fmt.Sprintf(" (#%d)\n", t.Id))
+		}
+
+		if showProjects {
+			builder.WriteRune('\n')
+		}
+	}
+
+	return builder.String()
+}
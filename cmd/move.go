@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/drazengolic/gitodo/ui"
+	"github.com/spf13/cobra"
+)
+
+// moveCmd represents the move command
+var moveCmd = &cobra.Command{
+	Use:   "move item",
+	Short: "Move or copy a to-do item to another branch or the queue",
+	Long: `
+Move a to-do item to the bottom of another branch's list, or to the repository
+queue. Sub-items are moved together with the item.
+
+Set the --to-branch flag to move the item to another branch, the --pick flag
+to pick the branch from a list, or the --queue flag to move it to the queue.
+Without the flags, the item is moved to the current branch, which is useful
+for taking items from the queue.
+
+Set the --copy flag to copy the item instead. Copies are not completed.
+
+Completed items are kept where the work was done, and they can't be moved,
+only copied.
+` + itemRefHelp + `
+
+Set the --json flag to print the item as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Println("Invalid number of arguments.")
+			os.Exit(1)
+		}
+
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		copyItem := cmd.Flags().Changed("copy")
+		item := mustFindItem(tdb, env, args[0])
+		if item.DoneAt.Valid && !copyItem {
+			ExitOnError(fmt.Errorf("Item #%d is done and can't be moved.", item.Id), 1)
+		}
+
+		verb, action := "Move", "Moved"
+		if copyItem {
+			verb, action = "Copy", "Copied"
+		}
+
+		branch := env.Branch
+		switch {
+		case cmd.Flags().Changed("queue"):
+			branch = "*"
+		case cmd.Flags().Changed("to-branch"):
+			branch, _ = cmd.Flags().GetString("to-branch")
+		case cmd.Flags().Changed("pick"):
+			names, options, err := ui.BranchOptions(tdb, env.ProjDir, tdb.GetProject(item.ProjectId).Branch)
+			ExitOnError(err, 1)
+			if len(names) == 0 {
+				ExitOnError(errors.New("There are no other branches."), 1)
+			}
+
+			i, err := ui.RunChooser(fmt.Sprintf("%s item #%d to branch:", verb, item.Id), options)
+			ExitOnError(err, 1)
+			if i < 0 {
+				return
+			}
+			branch = names[i]
+		}
+
+		destId := tdb.FetchProjectId(env.ProjDir, branch)
+		if destId == item.ProjectId {
+			ExitOnError(fmt.Errorf("Item #%d is already there.", item.Id), 1)
+		}
+
+		itemId := item.Id
+		if copyItem {
+			ids, err := tdb.CopyTodos([]int{item.Id}, destId)
+			ExitOnError(err, 1)
+			itemId = ids[0]
+		} else {
+			err = tdb.MoveTodo(item.Id, destId)
+			ExitOnError(err, 1)
+		}
+
+		if jsonMode {
+			printItemJSON(tdb.GetTodo(itemId))
+			return
+		}
+
+		if branch == "*" {
+			fmt.Printf("%s item #%d to the queue\n", action, item.Id)
+		} else {
+			fmt.Printf("%s item #%d to %q\n", action, item.Id, branch)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(moveCmd)
+	moveCmd.Flags().StringP("to-branch", "b", "", "Move the item to the given branch")
+	moveCmd.Flags().BoolP("queue", "q", false, "Move the item to the queue")
+	moveCmd.Flags().BoolP("pick", "p", false, "Pick the branch from a list")
+	moveCmd.Flags().BoolP("copy", "c", false, "Copy the item instead of moving it")
+	moveCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+	moveCmd.MarkFlagsMutuallyExclusive("to-branch", "queue", "pick")
+	moveCmd.MarkFlagsMutuallyExclusive("pick", "json")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// mvCmd represents the mv command
+var mvCmd = &cobra.Command{
+	Use:   "mv item position",
+	Short: "Change the position of a to-do item",
+	Long: `
+Change the position of a to-do item within its list. Position 1 is the top of
+the list, and positions larger than the number of items put the item at the
+bottom.
+` + itemRefHelp + `
+
+Set the --json flag to print the item as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Println("Invalid number of arguments.")
+			os.Exit(1)
+		}
+
+		to, err := strconv.Atoi(args[1])
+		if err != nil || to < 1 {
+			fmt.Printf("'%s' is not a valid position.\n", args[1])
+			os.Exit(1)
+		}
+
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err = tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		item := mustFindItem(tdb, env, args[0])
+		to = min(to, tdb.TodoCount(item.ProjectId))
+
+		err = tdb.ChangePosition(item.Id, item.Position, to)
+		ExitOnError(err, 1)
+
+		if jsonMode {
+			printItemJSON(tdb.GetTodo(item.Id))
+			return
+		}
+
+		fmt.Printf("Moved item #%d to position %d\n", item.Id, to)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(mvCmd)
+	mvCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
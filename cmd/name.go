@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/drazengolic/gitodo/base"
 	"github.com/spf13/cobra"
 )
 
@@ -36,15 +37,58 @@ with the branch name.
 When no argument is given, the command will output the current name.
 
 If there are arguments provided, the first one will be used to set the project
-name (no text join will happen, so make sure to use quotes).`,
+name (no text join will happen, so make sure to use quotes).
+
+Branches can also have a ticket id of an issue tracker, which is included in
+commit messages and reports, and used by the "open" command. Set it with the
+--ticket flag, remove it with the --clear-ticket flag, or display it with the
+--show-ticket flag.
+
+The name and the ticket id can be set automatically when a branch is used for
+the first time, with regular expressions set with the gitodo.nameRule git
+config key (multiple values are allowed, and the first matching one is used).
+The named groups "ticket" and "name" capture the ticket id and the name, where
+hyphens and underscores in the name are replaced with spaces. For example:
+
+  git config --add gitodo.nameRule '^\w+/(?P<ticket>[A-Z]+-\d+)-(?P<name>.+)$'
+
+names the branch "feature/PROJ-123-some-slug" as "Some slug", with the ticket
+id "PROJ-123".
+
+Set the --json flag to print the project as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		proj := tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch))
 
-		if len(args) == 0 {
+		if len(args) > 0 {
+			err := tdb.UpdateProjectName(proj.Id, args[0])
+			ExitOnError(err, 1)
+		}
+
+		ticket, _ := cmd.Flags().GetString("ticket")
+		if cmd.Flags().Changed("ticket") && ticket == "" {
+			ExitOnError(fmt.Errorf("Ticket id can't be empty, use the --clear-ticket flag to remove it."), 1)
+		}
+
+		clearTicket := cmd.Flags().Changed("clear-ticket")
+		if ticket != "" || clearTicket {
+			err := tdb.UpdateProjectTicket(proj.Id, ticket)
+			ExitOnError(err, 1)
+		}
+
+		switch {
+		case jsonMode:
+			proj = tdb.GetProject(proj.Id)
+			printJSON(map[string]*base.Project{"project": &proj})
+		case cmd.Flags().Changed("show-ticket"):
+			fmt.Println(proj.Ticket)
+		case clearTicket && len(args) == 0:
+			fmt.Println("ticket cleared")
+		case cmd.Flags().Changed("ticket") && len(args) == 0:
+			fmt.Printf("ticket set to \"%s\"\n", ticket)
+		case len(args) == 0:
 			fmt.Println(proj.Name)
-		} else {
-			tdb.UpdateProjectName(proj.Id, args[0])
+		default:
 			fmt.Printf("name set to \"%s\"\n", args[0])
 		}
 	},
@@ -52,4 +96,9 @@ name (no text join will happen, so make sure to use quotes).`,
 
 func init() {
 	RootCmd.AddCommand(nameCmd)
+	nameCmd.Flags().StringP("ticket", "t", "", "Set the ticket id")
+	nameCmd.Flags().Bool("clear-ticket", false, "Remove the ticket id")
+	nameCmd.Flags().Bool("show-ticket", false, "Display the ticket id")
+	nameCmd.MarkFlagsMutuallyExclusive("ticket", "clear-ticket", "show-ticket")
+	nameCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
 }
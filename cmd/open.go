@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// openCmd represents the open command
+var openCmd = &cobra.Command{
+	Use:   "open [ticket]",
+	Short: "Open the ticket of the branch in the browser",
+	Args:  cobra.MaximumNArgs(1),
+	Long: `
+Open the ticket of the current branch, or the given one, in the issue tracker.
+
+The URL of the ticket is made from the template set with the gitodo.ticketUrl
+git config key, where {ticket} is replaced with the ticket id, i.e.:
+
+  git config gitodo.ticketUrl 'https://example.atlassian.net/browse/{ticket}'
+
+See "gitodo help name" for setting the ticket id of the branch.
+
+Set the --print flag to print the URL instead of opening it.
+
+Set the --json flag to print the ticket id and the URL as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+
+		var ticket string
+		if len(args) > 0 {
+			ticket = args[0]
+		} else {
+			ticket = tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch)).Ticket
+		}
+
+		if ticket == "" {
+			ExitOnError(fmt.Errorf("No ticket set for %q.", env.Branch), 1)
+		}
+
+		template, _ := shell.GitConfig("gitodo.ticketUrl")
+		if !strings.Contains(template, "{ticket}") {
+			ExitOnError(errors.New("The gitodo.ticketUrl git config key must be set to a URL containing {ticket}."), 1)
+		}
+
+		link := strings.ReplaceAll(template, "{ticket}", url.PathEscape(ticket))
+
+		switch {
+		case jsonMode:
+			printJSON(map[string]string{"ticket": ticket, "url": link})
+		case cmd.Flags().Changed("print"):
+			fmt.Println(link)
+		default:
+			err := shell.OpenURL(link)
+			ExitOnError(err, 1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(openCmd)
+	openCmd.Flags().BoolP("print", "p", false, "Print the URL instead of opening it")
+	openCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
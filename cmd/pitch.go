@@ -39,16 +39,35 @@ point for the new branch.
 If --stash is provided, any changes will be stashed before checking out. When
 there is an active to-do item, the stash will reference the item.
 
-Project name can be also set by setting the --name flag.`,
+Project name can be also set by setting the --name flag.
+
+If the --template flag is provided, the items of the template with the given
+name are added instead. Otherwise, if the branch has no items yet and matches
+the branch patterns of a template, that template is applied automatically
+unless the --no-template flag is set. See "gitodo help template" for details.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			fmt.Println("Branch name not provided in the arguments.")
 			os.Exit(1)
 		}
 
+		template, _ := cmd.Flags().GetString("template")
+		if template != "" && len(args) > 1 {
+			fmt.Println("Items can't be given as arguments together with a template.")
+			os.Exit(1)
+		}
+
 		env, tdb := MustInit()
 		activeProj := tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch))
 
+		// read the template before stashing and checking out
+		var tmplItems []shell.Item
+		if template != "" {
+			var err error
+			tmplItems, err = templateItems(mustFindTemplate(env, tdb, template))
+			ExitOnError(err, 1)
+		}
+
 		_, err := tdb.CheckTimer(activeProj.Id)
 		HandleTimerError(err)
 
@@ -94,10 +113,22 @@ Project name can be also set by setting the --name flag.`,
 		itemCount := 0
 
 		// add items
-		if len(args) > 1 {
+		switch {
+		case template != "":
+			err := tdb.AddTodoTree(projId, 0, todoInputs(tmplItems))
+			ExitOnError(err, 1)
+			itemCount = len(tmplItems)
+		case len(args) > 1:
 			tdb.AddTodos(projId, args[1:])
 			itemCount = len(args) - 1
-		} else {
+		default:
+			if !cmd.Flags().Changed("no-template") {
+				itemCount = applyBranchTemplate(env, tdb, projId)
+			}
+			if itemCount > 0 {
+				break
+			}
+
 			tmpfile, err := shell.NewItemsTmpFile()
 			ExitOnError(err, 1)
 			err = tmpfile.Edit(env.Editor, 3)
@@ -107,7 +138,7 @@ Project name can be also set by setting the --name flag.`,
 			tmpfile.Delete()
 
 			ExitOnError(err, 1)
-			tdb.AddTodos(projId, items)
+			tdb.AddTodoTree(projId, 0, todoInputs(items))
 			itemCount = len(items)
 		}
 
@@ -126,4 +157,6 @@ func init() {
 	pitchCmd.Flags().StringP("base", "b", "", "Starting point (base) for the new branch")
 	pitchCmd.Flags().StringP("name", "n", "", "Project name")
 	pitchCmd.Flags().BoolP("stash", "s", false, "Stash changes before checkout")
+	pitchCmd.Flags().StringP("template", "t", "", "Add the items of the template")
+	pitchCmd.Flags().Bool("no-template", false, "Don't apply the template matching the branch")
 }
@@ -42,7 +42,7 @@ item.`,
 		projId := tdb.FetchProjectId(env.ProjDir, "*")
 
 		if len(args) > 0 {
-			tdb.AddTodo(projId, strings.Join(args, " "))
+			addItem(tdb, projId, strings.Join(args, " "))
 		} else {
 			tmpfile, err := shell.NewItemsTmpFile()
 			ExitOnError(err, 1)
@@ -53,7 +53,7 @@ item.`,
 			tmpfile.Delete()
 
 			ExitOnError(err, 1)
-			err = tdb.AddTodos(projId, items)
+			err = tdb.AddTodoTree(projId, 0, todoInputs(items))
 			ExitOnError(err, 1)
 			fmt.Printf("Queued %d item(s).\n", len(items))
 		}
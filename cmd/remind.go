@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/gen2brain/beeep"
+	"github.com/spf13/cobra"
+)
+
+// remindCmd represents the remind command
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "Notify about items that are due",
+	Long: `
+Send desktop notifications for the items across all repositories that are due
+today or overdue and not completed yet, one notification per branch, and print
+the items.
+
+The command can be executed anywhere, it is not required to be within a git
+repository, so it can be run periodically i.e. from a cron job or on login.
+
+Set the --silent flag to print the items without sending notifications.
+
+Set the --json flag to print the items as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tdb, err := base.NewTodoDb()
+		ExitOnError(err, 1)
+
+		today := time.Now().Format(time.DateOnly)
+		groups := []*listGroup{}
+
+		err = tdb.FilterTodos(base.TodoFilter{Pending: true, DueBy: today}, func(p base.Project, t base.Todo) {
+			if len(groups) == 0 || groups[len(groups)-1].Project.Id != p.Id {
+				groups = append(groups, &listGroup{Project: p, Items: []base.Todo{}})
+			}
+			g := groups[len(groups)-1]
+			g.Items = append(g.Items, t)
+		})
+		ExitOnError(err, 1)
+
+		if !cmd.Flags().Changed("silent") {
+			for _, g := range groups {
+				lines := make([]string, len(g.Items))
+				for i, t := range g.Items {
+					lines[i] = "• " + t.Task
+					if t.IsOverdue(today) {
+						lines[i] += " (overdue since " + t.DueAt.String + ")"
+					}
+				}
+				title := fmt.Sprintf("%s: %s", filepath.Base(g.Project.Folder), listGroupTitle(&g.Project))
+				beeep.Notify(title, strings.Join(lines, "\n"), "")
+			}
+		}
+
+		if jsonMode {
+			printJSON(map[string][]*listGroup{"projects": groups})
+			return
+		}
+
+		if len(groups) == 0 {
+			fmt.Println("Nothing due today.")
+			return
+		}
+
+		fmt.Print(listPlain(groups, true, true))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(remindCmd)
+	remindCmd.Flags().BoolP("silent", "s", false, "Print the items without sending notifications")
+	remindCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
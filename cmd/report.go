@@ -37,8 +37,12 @@ var reportCmd = &cobra.Command{
 	Short: "View activity report",
 	Long: `
 View the activity report for a given period of time that displays repositories,
-projects/branches, completed items, added but not completed items, and
-recorded time if any.
+projects/branches, completed items, added but not completed items, items that
+are due by the end of the period but not completed, estimates compared to the
+tracked time, and recorded time if any.
+
+The time of a completed item is the time tracked on its branch since the item
+was created or since the previous item was completed, whichever is later.
 
 The command can be executed anywhere, it is not required to be within a git
 repository.
@@ -165,13 +169,21 @@ When exporting to JSON, every timestamp will be converted to UTC.
 			for _, proj := range repo.Projects {
 				if proj.Proj.Branch != proj.Proj.Name {
 					builder.WriteString(txtRender(proj.Proj.Name, &blueText, useColors))
+					if proj.Proj.Ticket != "" {
+						builder.WriteString(txtRender(" ["+proj.Proj.Ticket+"]", &blueText, useColors))
+					}
 					builder.WriteRune('\n')
 					builder.WriteString(txtRender(proj.Proj.Branch, &dimmedText, useColors))
 				} else {
 					builder.WriteString(txtRender(proj.Proj.Branch, &blueText, useColors))
+					if proj.Proj.Ticket != "" {
+						builder.WriteString(txtRender(" ["+proj.Proj.Ticket+"]", &blueText, useColors))
+					}
 				}
 
 				switch {
+				case proj.LatestUpdate == "":
+					// only due items, no activity
 				case strings.HasPrefix(proj.LatestUpdate, today):
 					builder.WriteString(txtRender(" • updated today", &dimmedText, useColors))
 				case strings.HasPrefix(proj.LatestUpdate, yesterday):
@@ -192,7 +204,45 @@ When exporting to JSON, every timestamp will be converted to UTC.
 				if len(proj.CreatedItems) > 0 {
 					builder.WriteString("\nAdded:\n")
 					for _, item := range proj.CreatedItems {
-						builder.WriteString(fmt.Sprintf("  - %s\n", item.Task))
+						builder.WriteString(fmt.Sprintf("  - %s", item.Task))
+						if item.DueAt.Valid {
+							builder.WriteString(txtRender(" • due "+item.DueAt.String, &dimmedText, useColors))
+						}
+						builder.WriteRune('\n')
+					}
+				}
+
+				if len(proj.DueItems) > 0 {
+					builder.WriteString("\nDue:\n")
+					for _, item := range proj.DueItems {
+						builder.WriteString(fmt.Sprintf("  - %s ", item.Task))
+						switch {
+						case item.DueAt.String < today:
+							builder.WriteString(txtRender("• overdue since "+item.DueAt.String, &redText, useColors))
+						case item.DueAt.String == today:
+							builder.WriteString(txtRender("• due today", &orangeText, useColors))
+						default:
+							builder.WriteString(txtRender("• due "+item.DueAt.String, &dimmedText, useColors))
+						}
+						builder.WriteRune('\n')
+					}
+				}
+
+				if proj.Estimate != nil || len(proj.Estimates) > 0 {
+					builder.WriteString("\nEstimates:\n")
+					if proj.Estimate != nil {
+						builder.WriteString(fmt.Sprintf("  %s estimated, %s tracked in total",
+							base.FormatEstimate(proj.Estimate.TotalSec()), base.FormatEstimate(proj.Estimate.ElapsedSec)))
+						builder.WriteString(txtRender(", "+base.FormatEstimate(proj.Estimate.RemainingSec)+" remaining", &dimmedText, useColors))
+						builder.WriteRune('\n')
+					}
+					for _, e := range proj.Estimates {
+						builder.WriteString(fmt.Sprintf("  - %s • %s estimated, took %s", e.Task,
+							base.FormatEstimate(e.EstimateSec), base.FormatEstimate(e.ActualSec)))
+						if e.ActualSec > e.EstimateSec {
+							builder.WriteString(txtRender(" (+"+base.FormatEstimate(e.ActualSec-e.EstimateSec)+")", &redText, useColors))
+						}
+						builder.WriteRune('\n')
 					}
 				}
 
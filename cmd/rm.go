@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/spf13/cobra"
+)
+
+// rmCmd represents the rm command
+var rmCmd = &cobra.Command{
+	Use:   "rm item...",
+	Short: "Delete to-do items",
+	Long: `
+Delete to-do items given as arguments. Confirmation is asked for every item
+unless the --yes flag is set.
+
+Completed items are kept as a record of the work done, and they can't be
+deleted.
+` + itemRefHelp + `
+
+Set the --json flag to print the deleted items as a JSON object. Confirmation
+is not asked in that case, so the --yes flag must be set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("Items not provided in the arguments.")
+			os.Exit(1)
+		}
+
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		yes := cmd.Flags().Changed("yes")
+		if jsonMode && !yes {
+			ExitOnError(errors.New("The --yes flag must be set together with --json."), 1)
+		}
+
+		// resolve all of the items first, since positions change on delete
+		items := make([]*base.Todo, 0, len(args))
+		for _, ref := range args {
+			item := mustFindItem(tdb, env, ref)
+			if item.DoneAt.Valid {
+				ExitOnError(fmt.Errorf("Item #%d is done and can't be deleted.", item.Id), 1)
+			}
+			items = append(items, item)
+		}
+
+		deleted := []*base.Todo{}
+		for _, item := range items {
+			if !yes && !askYesNo(fmt.Sprintf("Delete %q?", item.Task)) {
+				continue
+			}
+			err := tdb.Delete(item.Id)
+			ExitOnError(err, 1)
+			deleted = append(deleted, item)
+		}
+
+		if jsonMode {
+			printJSON(map[string][]*base.Todo{"deleted": deleted})
+			return
+		}
+
+		fmt.Printf("Deleted %d item(s).\n", len(deleted))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(rmCmd)
+	rmCmd.Flags().BoolP("yes", "y", false, "Delete without asking")
+	rmCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -50,14 +51,33 @@ All configuration is read from git and the environment, no yaml files needed.
 
 Running the application without arguments will either:
 
-  - open up the editor to add items if none are found
-  - open a TUI screen where to-do items can be managed
+  - open up the editor to add items if none are found, unless there is
+    a template for the branch (see "gitodo help template")
+  - open a TUI screen where to-do items can be managed, and where the timer
+    can be started and stopped (if the timer is running for another branch,
+    the screen offers to stop it and start it for the current branch)
 
 The invoked editor will be the same one that git invokes.
 
+The TUI screen can be configured with git config. Set gitodo.theme to "mono"
+to use a theme without colors, which is also used when NO_COLOR is set. Keys
+can be rebound with gitodo.keys.<action>, i.e. gitodo.keys.pushTop "ctrl+t g",
+where the keys are separated with spaces or commas, "space" is the space bar,
+and an empty value disables the action. Actions are named: up, down, done,
+mark, markRange, pushTop, pushUp, pushDown, indent, outdent, collapse, expand,
+delete, confirm, cancel, edit, editExternal, notes, add, addExternal, move,
+branch, stash, popStash, stashes, filter, nextMatch, prevMatch, clear, timer,
+sessions, showId, help and quit.
+
 To-do items do not have a priority. The top-most item should be always the one 
 with the top priority, and commands like "what" and "done" read items from top
-to bottom. Use the TUI screen to change the order of the items.
+to bottom. Use the TUI screen to change the order of the items, either with
+the keys or by dragging the items over their siblings with the mouse. A click
+moves the cursor and a double-click marks the item as done.
+
+The stashes of the repository are browsed in the TUI screen with the Z key,
+where the stash under the cursor can be applied without dropping it, dropped,
+or linked to the current item. See "gitodo help stash" for the commands.
 
 When stashing changes for an item, the "--include-untracked" flag will be 
 passed to git, so if you don't want to have some untracked files to be stashed,
@@ -67,7 +87,19 @@ By default, gitodo will store the database file into the current user's home
 directory. To override the path to the database file, set GITODO_DB environment
 variable to a desired path to the file.
 
+Most of the commands accept the --json flag for use in scripts and editor
+plugins. In that case, errors are also printed as JSON objects under the
+"error" key, and the exit codes are 1 for general errors, 2 for timer errors
+and 3 when a timer is running for another project. Without the --json flag,
+the exit code is 1 for all errors.
+
+The database is backed up automatically before upgrades and before commands
+that delete data. See "gitodo help util backup" for details.
+
   `,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		jsonMode = cmd.Flags().Changed("json")
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
@@ -75,10 +107,16 @@ variable to a desired path to the file.
 		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
 		count := tdb.TodoCount(projId)
 
+		// the TUI offers to stop the timer running elsewhere and start it here
 		_, err := tdb.CheckTimer(projId)
-		HandleTimerError(err)
+		var elsewhere *base.TimerRunningElsewhereError
+		if count == 0 || !errors.As(err, &elsewhere) {
+			HandleTimerError(err)
+		}
 
-		if count == 0 {
+		if count == 0 && applyBranchTemplate(env, tdb, projId) > 0 {
+			ui.RunTodoListUI(env, tdb)
+		} else if count == 0 {
 			tmpfile, err := shell.NewItemsTmpFile()
 			ExitOnError(err, 1)
 			err = tmpfile.Edit(env.Editor, 3)
@@ -88,7 +126,7 @@ variable to a desired path to the file.
 			tmpfile.Delete()
 
 			ExitOnError(err, 1)
-			tdb.AddTodos(projId, items)
+			tdb.AddTodoTree(projId, 0, todoInputs(items))
 
 			fmt.Printf("Added %d item(s) to %q\n", len(items), env.Branch)
 		} else {
@@ -130,23 +168,48 @@ func MustInit() (*shell.DirEnv, *base.TodoDb) {
 	ExitOnError(err, 1)
 	tdb, err := base.NewTodoDb()
 	ExitOnError(err, 1)
+	rules, err := base.ParseNameRules(shell.GitConfigAll("gitodo.nameRule"))
+	ExitOnError(err, 1)
+	tdb.SetNameRules(rules)
 	return env, tdb
 }
 
 func ExitOnError(err error, code int) {
 	if err != nil {
-		fmt.Println(err.Error())
+		if jsonMode {
+			printJSONError(jsonError{Type: "error", Message: err.Error(), ExitCode: code})
+		} else {
+			fmt.Println(err.Error())
+		}
 		os.Exit(code)
 	}
 }
 
 func HandleTimerError(err error) {
-	if err != nil {
-		switch e := err.(type) {
-		case *base.TimerError:
-			fmt.Println(err.Error())
-		case *base.TimerRunningElsewhereError:
-			format := `There is a timer running for another project!
+	if err == nil {
+		return
+	}
+
+	switch e := err.(type) {
+	case *base.TimerError:
+		if jsonMode {
+			printJSONError(jsonError{Type: "timer", Message: e.Error(), ExitCode: ExitCodeTimer})
+			os.Exit(ExitCodeTimer)
+		}
+		fmt.Println(err.Error())
+		os.Exit(ExitCodeError)
+	case *base.TimerRunningElsewhereError:
+		if jsonMode {
+			printJSONError(jsonError{
+				Type:        "timer_running_elsewhere",
+				Message:     e.Error(),
+				ExitCode:    ExitCodeTimerElsewhere,
+				Project:     e.Proj,
+				DurationSec: e.Entry.Duration(),
+			})
+			os.Exit(ExitCodeTimerElsewhere)
+		}
+		format := `There is a timer running for another project!
 
 Repository: %q
 Branch: %s
@@ -154,10 +217,13 @@ Duration: %s
 
 To continue, please cd/checkout to the given repository/branch,
 or type "gitodo stop" (in any directory) to stop the active timer.`
-			fmt.Println(redText.Render(fmt.Sprintf(format, e.Proj.Folder, e.Proj.Branch, base.FormatSeconds(e.Entry.Duration()))))
-		default:
-			fmt.Println(redText.Render(e.Error()))
+		fmt.Println(redText.Render(fmt.Sprintf(format, e.Proj.Folder, e.Proj.Branch, base.FormatSeconds(e.Entry.Duration()))))
+		os.Exit(ExitCodeError)
+	default:
+		if jsonMode {
+			ExitOnError(err, ExitCodeError)
 		}
-		os.Exit(1)
+		fmt.Println(redText.Render(e.Error()))
+		os.Exit(ExitCodeError)
 	}
 }
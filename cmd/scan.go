@@ -0,0 +1,204 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/drazengolic/gitodo/ui"
+	"github.com/spf13/cobra"
+)
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Add to-do items from TODO comments in the code",
+	Long: `
+Scan the files changed on the current branch for TODO, FIXME and XXX comments,
+and pick the ones to be added as to-do items. The items keep the file:line
+reference of the comment, which is shown by the "list" command and in the
+details pane of the TUI screen.
+
+The changed files are the ones that differ from the base branch, along with
+uncommitted changes and untracked files. The base branch is set with the --base
+flag, or with the gitodo.baseBranch git config key, and defaults to main or
+master. Set the --all flag to scan all files tracked by git instead.
+
+Comments that already have items are not offered again. If the comments of
+pending items were removed from the code, the command offers to mark those
+items as done, and the references of the comments that were moved are updated.
+Comments without text can only be told apart by their line, so when they move,
+they are offered again and their old items are offered to be marked as done.
+
+Set the --yes flag to add all the comments and complete all the items without
+asking, i.e. when running without a terminal.
+
+Set the --json flag to print the new comments and the items with removed
+comments as a JSON object. Changes are applied only if --yes is set as well.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		var files []string
+		if cmd.Flags().Changed("all") {
+			files, err = shell.TrackedFiles(env.ProjDir)
+		} else {
+			base, _ := cmd.Flags().GetString("base")
+			if base == "" {
+				base = shell.DefaultBranch()
+			}
+			files, err = shell.ChangedFiles(env.ProjDir, base)
+		}
+		ExitOnError(err, 1)
+
+		markers, err := shell.ScanMarkers(env.ProjDir, files)
+		ExitOnError(err, 1)
+
+		// check the comments of the existing items
+		known := map[string]struct{}{}
+		removed := []base.Todo{}
+		moved := map[int]string{}
+
+		err = tdb.TodoItems(projId, func(t base.Todo) {
+			if !t.Source.Valid {
+				return
+			}
+
+			file, lastLine := t.Source.String, 0
+			if i := strings.LastIndex(file, ":"); i >= 0 {
+				lastLine, _ = strconv.Atoi(file[i+1:])
+				file = file[:i]
+			}
+			known[shell.Marker{File: file, Line: lastLine, Text: t.SourceText.String}.Key()] = struct{}{}
+
+			line := shell.FindMarker(env.ProjDir, file, t.SourceText.String, lastLine)
+			switch {
+			case line == 0 && !t.DoneAt.Valid:
+				removed = append(removed, t)
+			case line > 0 && t.Source.String != fmt.Sprintf("%s:%d", file, line):
+				moved[t.Id] = fmt.Sprintf("%s:%d", file, line)
+			}
+		})
+		ExitOnError(err, 1)
+
+		found := []shell.Marker{}
+		for _, m := range markers {
+			if _, ok := known[m.Key()]; !ok {
+				found = append(found, m)
+			}
+		}
+
+		yes := cmd.Flags().Changed("yes")
+
+		if jsonMode && !yes {
+			printJSON(struct {
+				Found   []shell.Marker `json:"found"`
+				Removed []base.Todo    `json:"removed"`
+			}{found, removed})
+			return
+		}
+
+		for id, source := range moved {
+			err = tdb.SetSource(id, source, tdb.GetTodo(id).SourceText.String)
+			ExitOnError(err, 1)
+		}
+
+		// pick the comments to add
+		if len(found) > 0 && !yes {
+			options := make([]ui.PickerOption, len(found))
+			for i, m := range found {
+				options[i] = ui.PickerOption{Label: markerTask(m), Detail: m.Source(), Selected: true}
+			}
+			selected, err := ui.RunPicker(fmt.Sprintf("Add comments as items to %q:", env.Branch), options)
+			ExitOnError(err, 1)
+
+			picked := make([]shell.Marker, len(selected))
+			for i, j := range selected {
+				picked[i] = found[j]
+			}
+			found = picked
+		}
+
+		if len(found) > 0 {
+			inputs := make([]base.TodoInput, len(found))
+			for i, m := range found {
+				inputs[i] = base.TodoInput{Task: markerTask(m), Source: m.Source(), SourceText: m.Text}
+			}
+			err = tdb.AddTodoTree(projId, 0, inputs)
+			ExitOnError(err, 1)
+		}
+
+		// pick the items to complete
+		if len(removed) > 0 && !yes {
+			options := make([]ui.PickerOption, len(removed))
+			for i, t := range removed {
+				options[i] = ui.PickerOption{Label: t.Task, Detail: t.Source.String, Selected: true}
+			}
+			selected, err := ui.RunPicker("Comments were removed, mark the items as done:", options)
+			ExitOnError(err, 1)
+
+			picked := make([]base.Todo, len(selected))
+			for i, j := range selected {
+				picked[i] = removed[j]
+			}
+			removed = picked
+		}
+
+		for _, t := range removed {
+			err = tdb.TodoDone(t.Id, true)
+			ExitOnError(err, 1)
+		}
+
+		if jsonMode {
+			printJSON(struct {
+				Added     []shell.Marker `json:"added"`
+				Completed []base.Todo    `json:"completed"`
+			}{found, removed})
+			return
+		}
+
+		if len(markers) == 0 && len(removed) == 0 {
+			fmt.Printf("No comments found in %d file(s).\n", len(files))
+			return
+		}
+
+		fmt.Printf("Added %d item(s), completed %d item(s).\n", len(found), len(removed))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(scanCmd)
+	scanCmd.Flags().BoolP("all", "a", false, "Scan all files tracked by git")
+	scanCmd.Flags().StringP("base", "b", "", "Base branch to compare the current branch with")
+	scanCmd.Flags().BoolP("yes", "y", false, "Add all comments and complete all items without asking")
+	scanCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+// markerTask returns the title of the item made from the comment
+func markerTask(m shell.Marker) string {
+	if m.Text == "" {
+		return fmt.Sprintf("%s in %s", m.Kind, m.Source())
+	}
+	return m.Text
+}
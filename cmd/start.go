@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/drazengolic/gitodo/base"
 	"github.com/gen2brain/beeep"
 	"github.com/spf13/cobra"
 )
@@ -37,13 +38,25 @@ If the timer is already running, an error will be displayed.
 NOTE: only one timer can be active at any point in time! If a timer is active,
 and you try to make changes on a repository/branch other than the one that
 timer is running for, you'll have to stop it before you proceed with the 
-changes. Only "queue" command is allowed.`,
+changes. Only "queue" command is allowed.
+
+Set the --json flag to print the project and the timer state as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
-		_, err := tdb.StartTimer(projId)
+		te, err := tdb.StartTimer(projId)
 		HandleTimerError(err)
 
+		if jsonMode {
+			proj := tdb.GetProject(projId)
+			printJSON(struct {
+				Project *base.Project `json:"project"`
+				Timer   jsonTimer     `json:"timer"`
+			}{Project: &proj, Timer: timerState(tdb, te, projId)})
+			beeep.Alert("gitodo", "Timer started.", "")
+			return
+		}
+
 		msg := fmt.Sprintf("Timer started on %s", time.Now().Format(time.ANSIC))
 		fmt.Println(msg)
 		beeep.Alert("gitodo", msg, "")
@@ -52,4 +65,5 @@ changes. Only "queue" command is allowed.`,
 
 func init() {
 	RootCmd.AddCommand(startCmd)
+	startCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
 }
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// stashCmd represents the stash command
+var stashCmd = &cobra.Command{
+	Use:   "stash",
+	Short: "Browse and manage stashes of to-do items",
+	Long: `
+Browse the stashes pushed for to-do items, which are stored in git with the
+gitodo_<id> message, where <id> is the id of the item. Stashes are pushed and
+popped from the TUI screen, or pushed by the "pitch" command.
+
+Stashes are referenced by the item they belong to, or by their git reference
+(i.e. stash@{1}) as printed by the "stash list" command.
+` + itemRefHelp,
+}
+
+// stash statuses, where orphaned stashes belong to deleted items
+const (
+	stashLinked   = "linked"
+	stashOrphaned = "orphaned"
+	stashUnlinked = "unlinked"
+)
+
+// stashInfo is a stash of the repository with the item it belongs to
+type stashInfo struct {
+	shell.StashEntry
+	Status     string `json:"status"`
+	Task       string `json:"task,omitempty"`
+	ItemBranch string `json:"item_branch,omitempty"`
+}
+
+func init() {
+	RootCmd.AddCommand(stashCmd)
+}
+
+// loadStashes reads the stashes of the repository and the items they belong to
+func loadStashes(tdb *base.TodoDb) ([]stashInfo, error) {
+	entries, err := shell.GetStashList()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, e := range entries {
+		if e.TodoId > 0 {
+			ids = append(ids, e.TodoId)
+		}
+	}
+
+	items := map[int]*base.ItemAndBranch{}
+	if len(ids) > 0 {
+		ibs, err := tdb.GetItemsAndBranch(ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, ib := range ibs {
+			items[ib.ItemId] = ib
+		}
+	}
+
+	result := make([]stashInfo, len(entries))
+	for i, e := range entries {
+		result[i] = stashInfo{StashEntry: e, Status: stashUnlinked}
+		if e.TodoId == 0 {
+			continue
+		}
+		if ib, ok := items[e.TodoId]; ok {
+			result[i].Status = stashLinked
+			result[i].Task, result[i].ItemBranch = ib.ItemName, ib.BranchName
+		} else {
+			result[i].Status = stashOrphaned
+		}
+	}
+
+	return result, nil
+}
+
+// mustFindStash finds the stash by its git reference, or the latest stash of
+// the referenced item, and exits if not found
+func mustFindStash(tdb *base.TodoDb, env *shell.DirEnv, ref string) stashInfo {
+	stashes, err := loadStashes(tdb)
+	ExitOnError(err, 1)
+
+	if strings.HasPrefix(ref, "stash@{") {
+		for _, s := range stashes {
+			if s.Ref == ref {
+				return s
+			}
+		}
+		ExitOnError(fmt.Errorf("Stash %s not found.", ref), 1)
+	}
+
+	item := mustFindItem(tdb, env, ref)
+	for _, s := range stashes {
+		if s.TodoId == item.Id {
+			return s
+		}
+	}
+
+	ExitOnError(fmt.Errorf("Item #%d has no stash.", item.Id), 1)
+	return stashInfo{}
+}
+
+// stashLabel describes the stash with the item it belongs to
+func stashLabel(s *stashInfo) string {
+	switch s.Status {
+	case stashLinked:
+		return fmt.Sprintf("#%d %s %s", s.TodoId, s.Task, blueText.Render("["+s.ItemBranch+"]"))
+	case stashOrphaned:
+		return fmt.Sprintf("gitodo_%d %s", s.TodoId, redText.Render("item deleted"))
+	default:
+		return s.Message + dimmedText.Render(" not linked")
+	}
+}
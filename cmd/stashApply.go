@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// stashApplyCmd represents the stashApply command
+var stashApplyCmd = &cobra.Command{
+	Use:   "apply stash",
+	Short: "Apply a stash without dropping it",
+	Args:  cobra.ExactArgs(1),
+	Long: `
+Apply the changes of the stash of an item, or the stash given by its git
+reference, to the working tree. Unlike popping the stash in the TUI screen,
+the stash is kept, so it can be applied again or dropped with "stash drop".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		s := mustFindStash(tdb, env, args[0])
+
+		err := shell.ApplyStash(s.Ref)
+		ExitOnError(err, 1)
+
+		fmt.Printf("Applied %s %s.\n", s.Ref, stashLabel(&s))
+	},
+}
+
+func init() {
+	stashCmd.AddCommand(stashApplyCmd)
+}
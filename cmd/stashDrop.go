@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// stashDropCmd represents the stashDrop command
+var stashDropCmd = &cobra.Command{
+	Use:   "drop [stash]",
+	Short: "Delete a stash",
+	Args:  cobra.MaximumNArgs(1),
+	Long: `
+Delete the stash of an item, or the stash given by its git reference. Set the
+--orphaned flag instead to delete all stashes of deleted items. Confirmation
+is asked for every stash unless the --yes flag is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		orphaned := cmd.Flags().Changed("orphaned")
+
+		var stashes []stashInfo
+		switch {
+		case orphaned && len(args) > 0:
+			ExitOnError(errors.New("A stash can't be given together with the --orphaned flag."), 1)
+		case orphaned:
+			all, err := loadStashes(tdb)
+			ExitOnError(err, 1)
+			stashes = slices.DeleteFunc(all, func(s stashInfo) bool { return s.Status != stashOrphaned })
+		case len(args) > 0:
+			stashes = []stashInfo{mustFindStash(tdb, env, args[0])}
+		default:
+			ExitOnError(errors.New("Stash not provided in the arguments."), 1)
+		}
+
+		yes := cmd.Flags().Changed("yes")
+		dropped := 0
+
+		// drop the oldest stashes first, so that the references
+		// of the remaining ones don't change
+		for _, s := range slices.Backward(stashes) {
+			if !yes && !askYesNo(fmt.Sprintf("Drop %s %s?", s.Ref, stashLabel(&s))) {
+				continue
+			}
+			err := shell.DropStash(s.Ref)
+			ExitOnError(err, 1)
+			dropped++
+		}
+
+		fmt.Printf("Dropped %d stash(es).\n", dropped)
+	},
+}
+
+func init() {
+	stashCmd.AddCommand(stashDropCmd)
+	stashDropCmd.Flags().BoolP("orphaned", "o", false, "Delete all stashes of deleted items")
+	stashDropCmd.Flags().BoolP("yes", "y", false, "Delete without asking")
+}
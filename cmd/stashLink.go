@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// stashLinkCmd represents the stashLink command
+var stashLinkCmd = &cobra.Command{
+	Use:   "link stash item",
+	Short: "Link a stash to an item",
+	Args:  cobra.ExactArgs(2),
+	Long: `
+Link the stash given by its git reference to the item, i.e. a stash pushed
+without an item, or an orphaned one. The stash is stored again with the
+message of the item, which makes it the latest stash (stash@{0}).
+
+An item can have only one stash, so the stash of the item must be dropped or
+popped first if there is one.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		s := mustFindStash(tdb, env, args[0])
+		item := mustFindItem(tdb, env, args[1])
+
+		stashes, err := loadStashes(tdb)
+		ExitOnError(err, 1)
+		for _, other := range stashes {
+			if other.TodoId == item.Id {
+				if other.Ref == s.Ref {
+					fmt.Printf("Stash %s is already linked to #%d.\n", s.Ref, item.Id)
+					return
+				}
+				ExitOnError(fmt.Errorf("Item #%d already has a stash (%s).", item.Id, other.Ref), 1)
+			}
+		}
+
+		err = shell.LinkStash(s.Ref, item.Id)
+		ExitOnError(err, 1)
+
+		fmt.Printf("Linked %s to #%d %q as stash@{0}.\n", s.Ref, item.Id, item.Task)
+	},
+}
+
+func init() {
+	stashCmd.AddCommand(stashLinkCmd)
+}
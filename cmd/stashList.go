@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+// stashListCmd represents the stashList command
+var stashListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List stashes",
+	Long: `
+List all stashes of the repository, the latest first, together with the items
+they belong to. Stashes of deleted items are listed as orphaned, and stashes
+that were not pushed for an item as not linked. Set the --orphaned flag to
+list only the orphaned stashes.
+
+Set the --json flag to print the stashes as a JSON array.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_, tdb := MustInit()
+		stashes, err := loadStashes(tdb)
+		ExitOnError(err, 1)
+
+		if cmd.Flags().Changed("orphaned") {
+			stashes = slices.DeleteFunc(stashes, func(s stashInfo) bool { return s.Status != stashOrphaned })
+		}
+
+		if jsonMode {
+			printJSON(stashes)
+			return
+		}
+
+		if len(stashes) == 0 {
+			fmt.Println("No stashes.")
+			return
+		}
+
+		for _, s := range stashes {
+			fmt.Printf("%s %s %s\n", s.Ref, stashLabel(&s), dimmedText.Render("• "+s.Date))
+		}
+	},
+}
+
+func init() {
+	stashCmd.AddCommand(stashListCmd)
+	stashListCmd.Flags().BoolP("orphaned", "o", false, "List only the stashes of deleted items")
+	stashListCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// stashShowCmd represents the stashShow command
+var stashShowCmd = &cobra.Command{
+	Use:   "show stash",
+	Short: "Show the changes of a stash",
+	Args:  cobra.ExactArgs(1),
+	Long: `
+Show the files changed in the stash of an item, or the stash given by its git
+reference, including untracked files. Set the --patch flag to show the diff.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		s := mustFindStash(tdb, env, args[0])
+
+		var out string
+		var err error
+		if cmd.Flags().Changed("patch") {
+			out, err = shell.StashPatch(s.Ref)
+		} else {
+			out, err = shell.StashStat(s.Ref)
+		}
+		ExitOnError(err, 1)
+
+		fmt.Printf("%s %s %s\n\n", s.Ref, stashLabel(&s), dimmedText.Render("• "+s.Date))
+		fmt.Print(out)
+	},
+}
+
+func init() {
+	stashCmd.AddCommand(stashShowCmd)
+	stashShowCmd.Flags().BoolP("patch", "p", false, "Show the diff")
+}
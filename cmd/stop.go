@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/drazengolic/gitodo/base"
@@ -36,26 +35,40 @@ Stop the running timer.
 The command can be executed from anywhere, it is not required to be in the
 same repository or at the same branch where the timer has started.
 
-And error is displayed if no timer is running.`,
+And error is displayed if no timer is running.
+
+Set the --json flag to print the project, the session duration and the total
+time as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		tdb, err := base.NewTodoDb()
 		ExitOnError(err, 1)
 
-		_, prev, err := tdb.StopTimer()
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		te, prev, err := tdb.StopTimer()
+		HandleTimerError(err)
 
 		total, err := tdb.GetProjectTime(prev.ProjectId)
+		ExitOnError(err, 1)
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		proj := tdb.GetProject(prev.ProjectId)
+
+		if jsonMode {
+			printJSON(struct {
+				Project     *base.Project `json:"project"`
+				StartedAt   string        `json:"started_at"`
+				StoppedAt   string        `json:"stopped_at"`
+				DurationSec int           `json:"duration_sec"`
+				TotalSec    int           `json:"total_sec"`
+			}{
+				Project:     &proj,
+				StartedAt:   base.FormatUTC(prev.CreatedAt),
+				StoppedAt:   base.FormatUTC(te.CreatedAt),
+				DurationSec: prev.Duration(),
+				TotalSec:    total,
+			})
+			beeep.Alert("gitodo", "Timer stopped.", "")
+			return
 		}
 
-		proj := tdb.GetProject(prev.ProjectId)
 		fmt.Printf(
 			"Timer stopped on %s.\n\nRepository: %q\nBranch: %s\nDuration: %s\nTotal time: %s\n",
 			time.Now().Format(time.ANSIC),
@@ -70,4 +83,5 @@ And error is displayed if no timer is running.`,
 
 func init() {
 	RootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
 }
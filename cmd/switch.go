@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// switchCmd represents the switch command
+var switchCmd = &cobra.Command{
+	Use:     "switch branch",
+	Aliases: []string{"sw"},
+	Short:   "Switch branches, stashing and restoring changes",
+	Args:    cobra.ExactArgs(1),
+	Long: `
+Checkout the branch, but stash the uncommitted changes of the current branch
+first, including untracked files, and restore the changes that were stashed
+when leaving the branch the last time. The message of the stash mentions the
+active to-do item, if there is one, but the stash is not linked to the item,
+so it's not popped with the item in the TUI screen.
+
+The changes are restored with "git stash pop". If the stash can't be applied
+cleanly, i.e. because of conflicts, it's kept, so it can be dropped with
+"gitodo stash drop" once the conflicts are resolved.
+
+Set the --no-restore flag to keep the stash of the branch for later.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		branch := args[0]
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		if branch == env.Branch {
+			fmt.Printf("Already on %q.\n", branch)
+			return
+		}
+
+		branches, err := shell.ListBranches()
+		ExitOnError(err, 1)
+		if !slices.Contains(branches, branch) {
+			ExitOnError(fmt.Errorf("Branch %q not found, create it with \"gitodo pitch\".", branch), 1)
+		}
+
+		// stash changes
+		changes, err := shell.HasChanges()
+		ExitOnError(err, 1)
+		if changes {
+			todoId := 0
+			if todo := tdb.TodoWhat(projId); todo != nil {
+				todoId = todo.Id
+			}
+			err = shell.PushSwitchStash(todoId)
+			ExitOnError(err, 1)
+			fmt.Printf("Stashed changes of %q.\n", env.Branch)
+		}
+
+		if err = shell.CheckoutBranch(branch, "", false); err != nil {
+			// bring the changes back, since the branch stays the same
+			if changes {
+				if err := shell.PopStash("stash@{0}"); err != nil {
+					fmt.Println(redText.Render("Changes are kept in stash@{0}: " + err.Error()))
+				}
+			}
+			ExitOnError(err, 1)
+		}
+		fmt.Printf("Switched to %q.\n", branch)
+
+		if cmd.Flags().Changed("no-restore") {
+			return
+		}
+
+		// restore changes
+		stashes, err := shell.GetStashList()
+		ExitOnError(err, 1)
+		s, ok := shell.FindSwitchStash(stashes, branch)
+		if !ok {
+			return
+		}
+
+		if err = shell.PopStash(s.Ref); err != nil {
+			fmt.Println(orangeText.Render(fmt.Sprintf("Changes from %s could not be restored cleanly:", s.Ref)))
+			fmt.Printf("%s\n\n", err.Error())
+			fmt.Printf("The stash is kept, drop it with \"gitodo stash drop %s\" once the conflicts are resolved.\n", s.Ref)
+			os.Exit(1)
+		}
+		fmt.Printf("Restored changes from %s.\n", s.Ref)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(switchCmd)
+	switchCmd.Flags().Bool("no-restore", false, "Don't restore the changes stashed for the branch")
+}
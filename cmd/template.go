@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage templates of to-do lists",
+	Long: `
+Manage templates, which are named lists of items that can be added to any
+branch, i.e. chores that every feature branch needs.
+
+` + templateHelp,
+}
+
+// templateHelp describes where the templates come from in command docs
+const templateHelp = `Templates are stored in the database with the "template edit" command, or read
+from a file set with the gitodo.templates git config key, where every template
+starts with its name in square brackets, followed by the items:
+
+  [release] release/* hotfix/*
+  - update the changelog
+  - bump the version
+
+The patterns after the name are optional. When a new branch matches any of
+them, the template is applied automatically by the "pitch" command, or when
+running gitodo without arguments. Templates in the database take precedence
+over the ones in the file with the same name. Relative paths of the file are
+resolved from the root of the repository.`
+
+func init() {
+	RootCmd.AddCommand(templateCmd)
+}
+
+// loadTemplates reads the templates from the database, followed by the ones
+// from the file set in git config that are not in the database. The templates
+// from the database are returned even if the file can't be read.
+func loadTemplates(env *shell.DirEnv, tdb *base.TodoDb) ([]base.Template, error) {
+	templates, err := tdb.GetTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	file, ok := shell.GitConfigPath("gitodo.templates")
+	if !ok || file == "" {
+		return templates, nil
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(env.ProjDir, file)
+	}
+
+	sections, err := shell.ReadTemplatesFile(file)
+	if err != nil {
+		return templates, err
+	}
+
+	for _, s := range sections {
+		if !slices.ContainsFunc(templates, func(t base.Template) bool { return t.Name == s.Name }) {
+			templates = append(templates, base.Template{Name: s.Name, Branches: s.Branches, Items: s.Items, File: file})
+		}
+	}
+
+	return templates, nil
+}
+
+// mustFindTemplate finds the template by name, or exits if not found
+func mustFindTemplate(env *shell.DirEnv, tdb *base.TodoDb, name string) *base.Template {
+	templates, err := loadTemplates(env, tdb)
+	ExitOnError(err, 1)
+
+	i := slices.IndexFunc(templates, func(t base.Template) bool { return t.Name == name })
+	if i < 0 {
+		ExitOnError(fmt.Errorf("Template %q not found.", name), 1)
+	}
+
+	return &templates[i]
+}
+
+// branchTemplate returns the first template that matches the branch,
+// or nil if there is none. Templates that can't be read are ignored.
+func branchTemplate(env *shell.DirEnv, tdb *base.TodoDb, branch string) *base.Template {
+	templates, _ := loadTemplates(env, tdb)
+
+	for _, t := range templates {
+		if t.MatchesBranch(branch) {
+			return &t
+		}
+	}
+
+	return nil
+}
+
+// templateItems parses the items of the template
+func templateItems(t *base.Template) ([]shell.Item, error) {
+	items, err := shell.ParseItems(strings.NewReader(t.Items))
+	if err == nil && len(items) == 0 {
+		err = fmt.Errorf("Template %q has no items.", t.Name)
+	}
+	return items, err
+}
+
+// applyBranchTemplate adds the items of the template matching the branch
+// if the project has no items yet, and returns the number of added items
+func applyBranchTemplate(env *shell.DirEnv, tdb *base.TodoDb, projId int) int {
+	if tdb.TodoCount(projId) > 0 {
+		return 0
+	}
+
+	t := branchTemplate(env, tdb, env.Branch)
+	if t == nil {
+		return 0
+	}
+
+	items, err := templateItems(t)
+	ExitOnError(err, 1)
+	err = tdb.AddTodoTree(projId, 0, todoInputs(items))
+	ExitOnError(err, 1)
+
+	fmt.Printf("Applied template %q to %q.\n", t.Name, env.Branch)
+	return len(items)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/spf13/cobra"
+)
+
+// templateDeleteCmd represents the templateDelete command
+var templateDeleteCmd = &cobra.Command{
+	Use:     "delete name...",
+	Aliases: []string{"rm"},
+	Short:   "Delete templates",
+	Args:    cobra.MinimumNArgs(1),
+	Long: `
+Delete the templates stored in the database by name. Templates read from the
+file have to be removed from the file instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		templates, _ := loadTemplates(env, tdb)
+
+		for _, name := range args {
+			i := slices.IndexFunc(templates, func(t base.Template) bool { return t.Name == name })
+
+			if i >= 0 && templates[i].File != "" {
+				fmt.Printf("Template %q is read from %s.\n", name, templates[i].File)
+			} else if err := tdb.DeleteTemplate(name); err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Printf("Deleted template %q.\n", name)
+			}
+		}
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateDeleteCmd)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// templateEditCmd represents the templateEdit command
+var templateEditCmd = &cobra.Command{
+	Use:   "edit name",
+	Short: "Create or edit a template",
+	Args:  cobra.ExactArgs(1),
+	Long: `
+Create or edit the template with the given name in the editor, using the same
+format as when adding items. Templates read from the file are saved into the
+database when edited.
+
+Set the --branches flag to the space separated patterns of the branch names
+(i.e. "release/* hotfix/*") that the template is applied to automatically, or
+to an empty string to turn it off.
+
+` + dueHelp,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		templates, err := loadTemplates(env, tdb)
+		ExitOnError(err, 1)
+
+		t := &base.Template{Name: strings.TrimSpace(args[0])}
+		if t.Name == "" {
+			ExitOnError(errors.New("The name of the template can't be empty."), 1)
+		}
+		if i := slices.IndexFunc(templates, func(t2 base.Template) bool { return t2.Name == t.Name }); i >= 0 {
+			t = &templates[i]
+		}
+
+		if cmd.Flags().Changed("branches") {
+			branches, _ := cmd.Flags().GetString("branches")
+			t.Branches = strings.Join(strings.Fields(branches), " ")
+		}
+
+		content := fmt.Sprintf("# Items of template %q, in the same format as when adding items.\n", t.Name)
+		if t.Items == "" {
+			content += "- "
+		} else {
+			content += t.Items + "\n"
+		}
+
+		tmp, err := shell.NewTmpFileString(content)
+		ExitOnError(err, 1)
+		err = tmp.Edit(env.Editor, 0)
+		text := tmp.ReadAll()
+		tmp.Delete()
+		ExitOnError(err, 1)
+
+		lines := slices.DeleteFunc(strings.Split(text, "\n"), func(line string) bool {
+			return strings.HasPrefix(line, "#")
+		})
+		t.Items = strings.TrimSpace(strings.Join(lines, "\n"))
+
+		_, err = templateItems(t)
+		ExitOnError(err, 1)
+		err = tdb.SaveTemplate(t)
+		ExitOnError(err, 1)
+
+		fmt.Printf("Saved template %q.\n", t.Name)
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateEditCmd)
+	templateEditCmd.Flags().StringP("branches", "b", "", "Patterns of the branch names to apply the template to")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// templateListCmd represents the templateList command
+var templateListCmd = &cobra.Command{
+	Use:     "list [name]",
+	Aliases: []string{"ls"},
+	Short:   "List templates",
+	Args:    cobra.MaximumNArgs(1),
+	Long: `
+List templates with their branch patterns and the number of items, or print
+the items of the template if the name is given.
+
+Set the --json flag to print the templates as a JSON array.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+
+		if len(args) > 0 {
+			t := mustFindTemplate(env, tdb, args[0])
+			if jsonMode {
+				printJSON(t)
+				return
+			}
+			fmt.Println(t.Items)
+			return
+		}
+
+		templates, err := loadTemplates(env, tdb)
+		if err != nil {
+			// still list the templates from the database
+			fmt.Println(redText.Render(err.Error()))
+		}
+
+		if jsonMode {
+			printJSON(templates)
+			return
+		}
+
+		if len(templates) == 0 {
+			fmt.Println("No templates.")
+			return
+		}
+
+		for _, t := range templates {
+			items, _ := templateItems(&t)
+			fmt.Printf("%s (%d)", t.Name, len(items))
+			if t.Branches != "" {
+				fmt.Print(blueText.Render(" " + t.Branches))
+			}
+			if t.File != "" {
+				fmt.Print(dimmedText.Render(" • " + t.File))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+	templateListCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
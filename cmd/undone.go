@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// undoneCmd represents the undone command
+var undoneCmd = &cobra.Command{
+	Use:   "undone [item]",
+	Short: "Set the last completed to-do item to not done",
+	Long: `
+Set the most recently completed to-do item of the current branch back to not
+done, i.e. to undo the "done" command.
+
+If an item is given as the argument, that item is set to not done instead.
+` + itemRefHelp + `
+
+Set the --json flag to print the item as a JSON object.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		projId := tdb.FetchProjectId(env.ProjDir, env.Branch)
+
+		_, err := tdb.CheckTimer(projId)
+		HandleTimerError(err)
+
+		item := tdb.TodoLastDone(projId)
+
+		if len(args) > 0 {
+			item = mustFindItem(tdb, env, args[0])
+		}
+
+		if item == nil || !item.DoneAt.Valid {
+			ExitOnError(errors.New("No completed item found."), 1)
+		}
+
+		err = tdb.TodoDone(item.Id, false)
+		ExitOnError(err, 1)
+
+		if jsonMode {
+			printItemJSON(tdb.GetTodo(item.Id))
+			return
+		}
+
+		fmt.Printf("%s\n%s\n", boldText.Render("To do again:"), item.Task)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(undoneCmd)
+	undoneCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/spf13/cobra"
+)
+
+// utilBackupCmd represents the utilBackup command
+var utilBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the database",
+	Long: `
+Make a backup copy of the database, or list the existing backups with the
+--list flag.
+
+Backups are also made automatically before the database structure is upgraded
+with a new version of the application, and before the commands that delete
+data, like "util delete" or "util fsck --fix".
+
+Backups are stored in the ".gitodo_backups" directory next to the database
+file. To store them elsewhere, set GITODO_BACKUP_DIR environment variable to
+a desired directory.
+
+Only the latest 10 backups are kept. To change the number, set the
+GITODO_BACKUP_KEEP environment variable. Setting it to 0 keeps all of the
+backups, but turns off the automatic ones.
+
+Use "util restore" command to restore the data from a backup.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flags().Changed("list") {
+			printBackups()
+			return
+		}
+
+		tdb, err := base.NewTodoDb()
+		ExitOnError(err, 1)
+		defer tdb.Close()
+
+		path, err := tdb.Backup(base.BackupReasonManual)
+		ExitOnError(err, 1)
+		fmt.Printf("Backup saved to %q\n", path)
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilBackupCmd)
+	utilBackupCmd.Flags().BoolP("list", "l", false, "List existing backups")
+}
+
+func printBackups() {
+	dir, err := base.BackupDir()
+	ExitOnError(err, 1)
+	files, err := base.ListBackups()
+	ExitOnError(err, 1)
+
+	if len(files) == 0 {
+		fmt.Printf("No backups found in %q\n", dir)
+		return
+	}
+
+	fmt.Println(dimmedText.Render(dir))
+	for _, f := range files {
+		fmt.Printf(
+			"%s  %s  %s\n",
+			filepath.Base(f.Path),
+			f.CreatedAt.Format(time.DateTime),
+			dimmedText.Render(fmt.Sprintf("%s, %d KB", f.Reason, f.Size/1024)),
+		)
+	}
+}
+
+// autoBackup makes a backup before destructive operations
+// and exits if it fails, so that no data can be lost
+func autoBackup(tdb *base.TodoDb, reason string) {
+	path, err := tdb.AutoBackup(reason)
+	if err != nil {
+		fmt.Println(redText.Render("Backup failed: " + err.Error()))
+		fmt.Println("To proceed without a backup, set GITODO_BACKUP_KEEP to 0.")
+		os.Exit(1)
+	}
+	if path != "" {
+		fmt.Println(dimmedText.Render("Backup saved to " + path))
+	}
+}
@@ -18,10 +18,9 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/drazengolic/gitodo/base"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
 )
 
 // utilDeleteCmd represents the utilDelete command
@@ -41,25 +40,18 @@ Delete to-do items and related data for all branch names provided as arguments.`
 		branches, err := tdb.GetBranches(env.ProjDir)
 		ExitOnError(err, 1)
 
-		key := make([]byte, 1)
+		backedUp := false
 
 		for _, b := range branches {
 			_, del := deleteMap[b.BranchName]
 
 			if !yes && del {
-				fmt.Printf("Delete %q? (y/n) ", b.BranchName)
-				// set up single key reading
-				oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				os.Stdin.Read(key)
-				term.Restore(int(os.Stdin.Fd()), oldState)
+				del = askYesNo(fmt.Sprintf("Delete %q?", b.BranchName))
+			}
 
-				r := key[0]
-				del = r == 'y' || r == 'Y'
-				fmt.Println("")
+			if del && !backedUp {
+				autoBackup(tdb, base.BackupReasonDelete)
+				backedUp = true
 			}
 
 			if del {
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// utilExportCmd represents the utilExport command
+var utilExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export to-do items for other applications",
+	Long: `
+Export the to-do items of the current branch in the format of another
+application to the standard output, or to the file set with the --output flag.
+
+Set the --all flag to export the items of all branches in the repository.
+
+` + formatHelp,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		format := mustGetFormat(cmd)
+
+		projects := []base.Project{}
+		if cmd.Flags().Changed("all") {
+			branches, err := tdb.GetBranches(env.ProjDir)
+			ExitOnError(err, 1)
+			for _, b := range branches {
+				projects = append(projects, tdb.GetProject(b.ProjectId))
+			}
+		} else {
+			projects = append(projects, tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch)))
+		}
+
+		var output []byte
+
+		switch format {
+		case formatTodoTxt:
+			output = []byte(shell.FormatTodoTxt(exportTodoTxt(tdb, projects)))
+		case formatTaskwarrior:
+			var err error
+			output, err = shell.FormatTaskwarrior(exportTaskwarrior(tdb, projects))
+			ExitOnError(err, 1)
+			output = append(output, '\n')
+		}
+
+		file, _ := cmd.Flags().GetString("output")
+		if file == "" || file == "-" {
+			fmt.Printf("%s", output)
+			return
+		}
+
+		err := os.WriteFile(file, output, 0644)
+		ExitOnError(err, 1)
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilExportCmd)
+	utilExportCmd.Flags().StringP("format", "f", formatTodoTxt, "Format of the file")
+	utilExportCmd.Flags().StringP("output", "o", "", "File to write the items to")
+	utilExportCmd.Flags().BoolP("all", "a", false, "Export items of all branches")
+}
+
+// exportTodoTxt converts the items of the projects to todo.txt items,
+// with the branch as the +project tag
+func exportTodoTxt(tdb *base.TodoDb, projects []base.Project) []shell.TodoTxtItem {
+	items := []shell.TodoTxtItem{}
+
+	for _, p := range projects {
+		err := tdb.TodoItems(p.Id, func(t base.Todo) {
+			t.Notes = ""
+			task := itemTokens(&t)
+			items = append(items, shell.TodoTxtItem{
+				Done:        t.DoneAt.Valid,
+				CompletedAt: dateOf(t.DoneAt.String),
+				CreatedAt:   dateOf(t.CreatedAt),
+				Task:        task,
+				Projects:    []string{p.Branch},
+			})
+		})
+		ExitOnError(err, 1)
+	}
+
+	return items
+}
+
+// exportTaskwarrior converts the items of the projects to Taskwarrior tasks,
+// with "repository/branch" as the project and the notes as the annotation
+func exportTaskwarrior(tdb *base.TodoDb, projects []base.Project) []shell.TaskwarriorTask {
+	tasks := []shell.TaskwarriorTask{}
+
+	for _, p := range projects {
+		uuids, err := tdb.ItemUUIDs(p.Id)
+		ExitOnError(err, 1)
+
+		err = tdb.TodoItems(p.Id, func(t base.Todo) {
+			task := shell.TaskwarriorTask{
+				UUID:        uuids[t.Id],
+				Description: t.Task,
+				Status:      shell.TaskwarriorPending,
+				Project:     filepath.Base(p.Folder) + "/" + p.Branch,
+				Entry:       taskwarriorTime(t.CreatedAt, time.DateTime),
+			}
+
+			if t.DoneAt.Valid {
+				task.Status = shell.TaskwarriorCompleted
+				task.End = taskwarriorTime(t.DoneAt.String, time.DateTime)
+			}
+			if t.DueAt.Valid {
+				task.Due = taskwarriorTime(t.DueAt.String, time.DateOnly)
+			}
+			if t.Notes != "" {
+				task.Annotations = []shell.TaskwarriorAnnotation{{Entry: task.Entry, Description: t.Notes}}
+			}
+
+			tasks = append(tasks, task)
+		})
+		ExitOnError(err, 1)
+	}
+
+	return tasks
+}
+
+// taskwarriorTime converts the local time in the given layout
+// to Taskwarrior format, or returns an empty string if invalid
+func taskwarriorTime(value, layout string) string {
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return ""
+	}
+	return shell.TaskwarriorTime(t)
+}
+
+// dateOf returns the date part of the time in YYYY-MM-DD HH:MM:SS format
+func dateOf(datetime string) string {
+	date, _, _ := strings.Cut(datetime, " ")
+	return date
+}
@@ -0,0 +1,190 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// utilFsckCmd represents the utilFsck command
+var utilFsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check and repair database integrity",
+	Long: `
+Check the database for inconsistencies and optionally repair them.
+
+The following is checked:
+
+ - positions of to-do items that contain gaps or duplicates, which can break
+   reordering of the items
+ - timer start and stop entries that can't be paired, or that are out of
+   order because of a change of the system clock
+ - repositories whose folders no longer exist
+ - stash entries in the current repository that reference deleted items
+
+By default, the command only reports the problems and what would be done to
+fix them. Set the --fix flag to apply the changes:
+
+ - positions are renumbered while keeping the existing order
+ - timer entries that can't be paired are deleted
+ - data for the missing repositories is deleted
+ - stash entries of deleted items are dropped, with confirmation asked for
+   every stash unless the --yes flag is set. Inspect them first with
+   "gitodo stash show", or link them to other items with "gitodo stash link".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		tdb, err := base.NewTodoDb()
+		ExitOnError(err, 1)
+
+		fix := cmd.Flags().Changed("fix")
+		problems := 0
+
+		if fix {
+			autoBackup(tdb, base.BackupReasonFix)
+		}
+
+		// missing folders go first since their items don't need fixing
+		fmt.Println(boldText.Render("Repositories:"))
+		projects, err := tdb.CheckProjectFolders()
+		ExitOnError(err, 1)
+
+		for _, p := range projects {
+			problems++
+			fmt.Printf("  %s: folder not found\n", fsckProjectLabel(&p))
+			if fix {
+				fsckResult(tdb.DeleteProject(p.Id), "data deleted")
+			}
+		}
+		fsckSectionDone(len(projects))
+
+		fmt.Println(boldText.Render("Item positions:"))
+		positions, err := tdb.CheckPositions()
+		ExitOnError(err, 1)
+
+		for _, pi := range positions {
+			problems++
+			proj := tdb.GetProject(pi.ProjectId)
+			switch {
+			case pi.HasDuplicates() && pi.HasGaps():
+				fmt.Printf("  %s: duplicate positions and gaps\n", fsckProjectLabel(&proj))
+			case pi.HasDuplicates():
+				fmt.Printf("  %s: duplicate positions\n", fsckProjectLabel(&proj))
+			default:
+				fmt.Printf("  %s: gaps in positions\n", fsckProjectLabel(&proj))
+			}
+			if fix {
+				fsckResult(tdb.FixPositions(pi.ProjectId), "renumbered")
+			}
+		}
+		fsckSectionDone(len(positions))
+
+		fmt.Println(boldText.Render("Timesheet:"))
+		entries, err := tdb.CheckTimesheet()
+		ExitOnError(err, 1)
+
+		ids := make([]int, 0, len(entries))
+		for _, issue := range entries {
+			problems++
+			proj := tdb.GetProject(issue.Entry.ProjectId)
+			var kind string
+			switch issue.Kind {
+			case base.TimesheetUnpairedStart:
+				kind = "start without a stop"
+			case base.TimesheetUnpairedStop:
+				kind = "stop without a start"
+			default:
+				kind = "out of order"
+			}
+			fmt.Printf("  #%d %s %s: %s\n", issue.Entry.Id, issue.Entry.CreatedAt, fsckProjectLabel(&proj), kind)
+			ids = append(ids, issue.Entry.Id)
+		}
+		if fix && len(ids) > 0 {
+			fsckResult(tdb.DeleteTimeEntries(ids), fmt.Sprintf("deleted %d entries", len(ids)))
+		}
+		fsckSectionDone(len(entries))
+
+		// stash is available only within a repository
+		fmt.Println(boldText.Render("Stash:"))
+		if _, err := shell.GetDirEnv(); err != nil {
+			fmt.Println(dimmedText.Render("  skipped, not in a git repository"))
+		} else {
+			stashes, err := loadStashes(tdb)
+			ExitOnError(err, 1)
+			stashes = slices.DeleteFunc(stashes, func(s stashInfo) bool { return s.Status != stashOrphaned })
+			yes := cmd.Flags().Changed("yes")
+
+			// drop the oldest stashes first, so that the references
+			// of the remaining ones don't change
+			for _, s := range slices.Backward(stashes) {
+				problems++
+				fmt.Printf("  %s (gitodo_%d): item not found\n", s.Ref, s.TodoId)
+				if fix && (yes || askYesNo(fmt.Sprintf("    Drop %s?", s.Ref))) {
+					fsckResult(shell.DropStash(s.Ref), "stash dropped")
+				}
+			}
+			fsckSectionDone(len(stashes))
+		}
+
+		fmt.Println("")
+
+		switch {
+		case problems == 0:
+			fmt.Println(greenTextStyle.Render("No problems found."))
+		case fix:
+			fmt.Print("Optimizing...")
+			err = tdb.Vacuum()
+			if err != nil {
+				fmt.Println(err.Error())
+			} else {
+				fmt.Println("ok")
+			}
+		default:
+			fmt.Println(orangeText.Render(fmt.Sprintf("Found %d problem(s). Run with --fix to repair them.", problems)))
+		}
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilFsckCmd)
+	utilFsckCmd.Flags().Bool("fix", false, "Repair the problems found")
+	utilFsckCmd.Flags().BoolP("yes", "y", false, "Drop the stashes of deleted items without asking")
+}
+
+func fsckProjectLabel(p *base.Project) string {
+	if p.Branch == "*" {
+		return fmt.Sprintf("%s (queue)", p.Folder)
+	}
+	return fmt.Sprintf("%s [%s]", p.Folder, p.Branch)
+}
+
+func fsckResult(err error, msg string) {
+	if err != nil {
+		fmt.Println(redText.Render("    " + err.Error()))
+	} else {
+		fmt.Println(greenTextStyle.Render("    " + msg))
+	}
+}
+
+func fsckSectionDone(count int) {
+	if count == 0 {
+		fmt.Println(dimmedText.Render("  ok"))
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/spf13/cobra"
+)
+
+// supported import and export formats
+const (
+	formatTodoTxt     = "todotxt"
+	formatTaskwarrior = "taskwarrior"
+)
+
+const formatHelp = `Supported formats, set with the --format flag:
+
+  todotxt      the todo.txt format (http://todotxt.org), one item per line
+  taskwarrior  the JSON format of Taskwarrior (https://taskwarrior.org)
+
+In the todo.txt format, the first +project tag of an item is the branch of the
+current repository, and @context tags are kept in the text of the item. The
+completion mark and dates map to completed items and the creation and
+completion dates, and due:YYYY-MM-DD and est:duration tokens to the due dates
+and estimates. The order of the items is kept, while the priorities are not
+used. Only the titles of the items are exported, without notes, and sub-items
+are exported as top level items right after their parents.
+
+In the Taskwarrior format, the project of a task is "repository/branch", where
+the repository is the name of the directory of the repository. The notes of the
+items are the annotations of the tasks, and the creation, completion and due
+dates are kept. Every exported item gets a uuid, so the tasks imported back are
+updated instead of being added again, and the same goes for "task import".
+Deleted and recurring tasks are not imported. Export the items with:
+
+  gitodo util export -f taskwarrior -a | task import
+  task export | gitodo util import -f taskwarrior`
+
+// utilImportCmd represents the utilImport command
+var utilImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import to-do items from other applications",
+	Long: `
+Import to-do items from a file in the format of another application, or from
+the standard input if the file is not given or is "-". The items are added at
+the end of the to-do lists of the branches in the current repository, in the
+same order as in the file.
+
+In the todo.txt format, items without a branch are added to the current branch,
+or to the branch set with the --branch flag. In the Taskwarrior format, tasks of
+the projects outside of the current repository are skipped, unless the --branch
+flag is set.
+
+` + formatHelp + `
+
+Set the --json flag to print the number of imported items per branch as a JSON
+object.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, tdb := MustInit()
+		format := mustGetFormat(cmd)
+
+		var input io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			ExitOnError(err, 1)
+			defer file.Close()
+			input = file
+		}
+
+		branch, _ := cmd.Flags().GetString("branch")
+		result := importResult{}
+
+		switch format {
+		case formatTodoTxt:
+			items, err := shell.ParseTodoTxt(input)
+			ExitOnError(err, 1)
+			if branch == "" {
+				branch = env.Branch
+			}
+			result.Branches = importTodoTxt(env, tdb, branch, items)
+		case formatTaskwarrior:
+			tasks, err := shell.ParseTaskwarrior(input)
+			ExitOnError(err, 1)
+			result = importTaskwarrior(env, tdb, branch, tasks)
+		}
+
+		if jsonMode {
+			printJSON(result)
+			return
+		}
+
+		if len(result.Branches) == 0 && result.Updated == 0 {
+			fmt.Println("No items to import.")
+		}
+
+		for _, b := range result.Branches {
+			fmt.Printf("Imported %d item(s) to %q\n", b.Count, b.Branch)
+		}
+		if result.Updated > 0 {
+			fmt.Printf("Updated %d item(s)\n", result.Updated)
+		}
+		if result.Skipped > 0 {
+			fmt.Println(dimmedText.Render(fmt.Sprintf("Skipped %d task(s) of other projects", result.Skipped)))
+		}
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilImportCmd)
+	utilImportCmd.Flags().StringP("format", "f", formatTodoTxt, "Format of the file")
+	utilImportCmd.Flags().StringP("branch", "b", "", "Branch for the items without one")
+	utilImportCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+type importedBranch struct {
+	Branch string `json:"branch"`
+	Count  int    `json:"count"`
+}
+
+type importResult struct {
+	Branches []importedBranch `json:"branches"`
+	Updated  int              `json:"updated"`
+	Skipped  int              `json:"skipped"`
+}
+
+// importBatch collects the imported items per branch,
+// keeping the order in which the branches were found
+type importBatch struct {
+	branches []string
+	inputs   map[string][]base.TodoInput
+}
+
+func (ib *importBatch) add(branch string, input base.TodoInput) {
+	if ib.inputs == nil {
+		ib.inputs = map[string][]base.TodoInput{}
+	}
+	if _, ok := ib.inputs[branch]; !ok {
+		ib.branches = append(ib.branches, branch)
+	}
+	ib.inputs[branch] = append(ib.inputs[branch], input)
+}
+
+// save adds the items at the end of the to-do lists of their branches
+func (ib *importBatch) save(env *shell.DirEnv, tdb *base.TodoDb) []importedBranch {
+	if _, ok := ib.inputs["*"]; ok {
+		ExitOnError(errors.New("Items can't be imported to the queue."), 1)
+	}
+
+	imported := make([]importedBranch, 0, len(ib.branches))
+	for _, b := range ib.branches {
+		projId := tdb.FetchProjectId(env.ProjDir, b)
+		err := tdb.AddTodoTree(projId, 0, ib.inputs[b])
+		ExitOnError(err, 1)
+		imported = append(imported, importedBranch{Branch: b, Count: len(ib.inputs[b])})
+	}
+
+	return imported
+}
+
+// mustGetFormat returns the value of the --format flag,
+// or exits if the format is not supported
+func mustGetFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	format = strings.ToLower(format)
+
+	switch format {
+	case formatTodoTxt, formatTaskwarrior:
+		return format
+	}
+
+	ExitOnError(fmt.Errorf("Unsupported format %q.", format), 1)
+	return ""
+}
+
+// importTodoTxt adds the todo.txt items to the branches of the repository
+// given by their first +project tag, or to the default branch
+func importTodoTxt(env *shell.DirEnv, tdb *base.TodoDb, branch string, items []shell.TodoTxtItem) []importedBranch {
+	now := time.Now()
+	batch := importBatch{}
+
+	for _, item := range items {
+		b, task := branch, item.Task
+		if len(item.Projects) > 0 {
+			b = item.Projects[0]
+			task = shell.RemoveTodoTxtProject(task, b)
+		}
+
+		task, due, _ := shell.ExtractDue(task, now)
+		task, estimate, _ := shell.ExtractEstimate(task)
+		if task == "" {
+			continue
+		}
+
+		input := base.TodoInput{Task: task, Due: due, Estimate: estimate}
+		if item.CreatedAt != "" {
+			input.CreatedAt = item.CreatedAt + " 00:00:00"
+		}
+		if item.Done {
+			input.DoneAt = now.Format(time.DateTime)
+			if item.CompletedAt != "" {
+				input.DoneAt = item.CompletedAt + " 00:00:00"
+			}
+			if input.CreatedAt == "" {
+				input.CreatedAt = input.DoneAt
+			}
+		}
+
+		batch.add(b, input)
+	}
+
+	return batch.save(env, tdb)
+}
+
+// importTaskwarrior adds the Taskwarrior tasks of the current repository to
+// their branches, or updates the items they were exported from. Tasks of other
+// projects are added to the given branch, or skipped if it's empty.
+func importTaskwarrior(env *shell.DirEnv, tdb *base.TodoDb, branch string, tasks []shell.TaskwarriorTask) importResult {
+	result := importResult{}
+	prefix := filepath.Base(env.ProjDir) + "/"
+	now := time.Now()
+	batch := importBatch{}
+
+	uuids := make([]string, len(tasks))
+	for i, t := range tasks {
+		uuids[i] = t.UUID
+	}
+	existing, err := tdb.FindUUIDs(uuids)
+	ExitOnError(err, 1)
+
+	for _, task := range tasks {
+		if task.Status == shell.TaskwarriorDeleted || task.Status == shell.TaskwarriorRecurring {
+			continue
+		}
+
+		text := strings.TrimSpace(task.Description)
+		notes := []string{}
+		for _, a := range task.Annotations {
+			notes = append(notes, a.Description)
+		}
+		if len(notes) > 0 {
+			text += "\n\n" + strings.Join(notes, "\n")
+		}
+		if text == "" {
+			continue
+		}
+
+		due := ""
+		if t, err := shell.ParseTaskwarriorTime(task.Due); err == nil && task.Due != "" {
+			due = t.Format(time.DateOnly)
+		}
+		done := task.Status == shell.TaskwarriorCompleted
+
+		if id, ok := existing[task.UUID]; ok && task.UUID != "" {
+			if updateTaskwarriorItem(tdb, id, text, due, done) {
+				result.Updated++
+			}
+			continue
+		}
+
+		b := branch
+		if strings.HasPrefix(task.Project, prefix) && len(task.Project) > len(prefix) {
+			b = task.Project[len(prefix):]
+		}
+		if b == "" {
+			result.Skipped++
+			continue
+		}
+
+		input := base.TodoInput{Task: text, Due: due, UUID: task.UUID}
+		if t, err := shell.ParseTaskwarriorTime(task.Entry); err == nil && task.Entry != "" {
+			input.CreatedAt = t.Format(time.DateTime)
+		}
+		if done {
+			input.DoneAt = now.Format(time.DateTime)
+			if t, err := shell.ParseTaskwarriorTime(task.End); err == nil && task.End != "" {
+				input.DoneAt = t.Format(time.DateTime)
+			}
+			if input.CreatedAt == "" {
+				input.CreatedAt = input.DoneAt
+			}
+		}
+
+		batch.add(b, input)
+	}
+
+	result.Branches = batch.save(env, tdb)
+	return result
+}
+
+// updateTaskwarriorItem updates the item from the task it was exported as,
+// and tells if there were any changes
+func updateTaskwarriorItem(tdb *base.TodoDb, id int, text, due string, done bool) bool {
+	item := tdb.GetTodo(id)
+	if item == nil {
+		return false
+	}
+
+	changed := false
+
+	if item.Text() != text {
+		ExitOnError(tdb.UpdateItemText(id, text), 1)
+		changed = true
+	}
+	if item.DueAt.String != due {
+		ExitOnError(tdb.SetDue(id, due), 1)
+		changed = true
+	}
+	if item.DoneAt.Valid != done {
+		ExitOnError(tdb.TodoDone(id, done), 1)
+		changed = true
+	}
+
+	return changed
+}
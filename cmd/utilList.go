@@ -29,7 +29,9 @@ var utilListCmd = &cobra.Command{
 	Short: "List branches with data",
 	Long: `
 List branches used with gitodo, together with a number of todo items.
-If a branch does not exist within the repository, it will be printed in red.`,
+If a branch does not exist within the repository, it will be printed in red.
+
+Set the --json flag to print the branches as a JSON array.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		projBranches, err := tdb.GetBranches(env.ProjDir)
@@ -42,6 +44,21 @@ If a branch does not exist within the repository, it will be printed in red.`,
 			branchMap[b] = struct{}{}
 		}
 
+		if jsonMode {
+			type branch struct {
+				Branch    string `json:"branch"`
+				ItemCount int    `json:"item_count"`
+				Exists    bool   `json:"exists"`
+			}
+			result := make([]branch, 0, len(projBranches))
+			for _, pb := range projBranches {
+				_, ok := branchMap[pb.BranchName]
+				result = append(result, branch{Branch: pb.BranchName, ItemCount: pb.ItemCount, Exists: ok})
+			}
+			printJSON(result)
+			return
+		}
+
 		for _, pb := range projBranches {
 			_, ok := branchMap[pb.BranchName]
 			txt := fmt.Sprintf("%s (%d)", pb.BranchName, pb.ItemCount)
@@ -56,4 +73,5 @@ If a branch does not exist within the repository, it will be printed in red.`,
 
 func init() {
 	utilCmd.AddCommand(utilListCmd)
+	utilListCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
 }
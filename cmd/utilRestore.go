@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/spf13/cobra"
+)
+
+// utilRestoreCmd represents the utilRestore command
+var utilRestoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Restore the database from a backup",
+	Long: `
+Restore the database from a backup file.
+
+The argument can be either a path to the file, or a name of the file in the
+backup directory as listed by "util backup --list". When no argument is given,
+the list of backups is printed.
+
+The current database is backed up before it gets replaced, so the restore can
+be undone by restoring that backup.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printBackups()
+			return
+		}
+
+		file := args[0]
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			dir, err := base.BackupDir()
+			ExitOnError(err, 1)
+			file = filepath.Join(dir, file)
+		}
+
+		tdb, err := base.NewTodoDb()
+		ExitOnError(err, 1)
+		defer tdb.Close()
+
+		err = tdb.Restore(file)
+		ExitOnError(err, 1)
+		fmt.Printf("Restored from %q\n", file)
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilRestoreCmd)
+}
@@ -18,6 +18,9 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/drazengolic/gitodo/base"
 	"github.com/drazengolic/gitodo/shell"
@@ -31,14 +34,18 @@ var whatCmd = &cobra.Command{
 	Short:   "Display what's next to do",
 	Long: `
 Display the first to-do item that isn't completed yet, starting from the top
-of the list.
+of the list, along with its notes.
 
-If there is no such item, "All done!" message will be shown.
+If there is no such item, "All done!" message will be shown. Pending items of
+the branch that are due today or overdue are listed below it.
 
 If there is a timer running, it will display the session time at the moment of
 the command execution. Also, if there are stashed changes assigned to any of
 the to-do items in the repository, the full list will be printed, organized by
-the branch name.`,
+the branch name.
+
+Set the --json flag to print the project, the item, the timer state and the
+stashed items as a JSON object.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		env, tdb := MustInit()
 		proj := tdb.GetProject(tdb.FetchProjectId(env.ProjDir, env.Branch))
@@ -46,14 +53,49 @@ the branch name.`,
 		te, err := tdb.CheckTimer(proj.Id)
 		HandleTimerError(err)
 
-		fmt.Printf("%s\n", blueText.Render(proj.Name))
+		if jsonMode {
+			printWhatJSON(tdb, &proj, te)
+			return
+		}
+
+		fmt.Print(blueText.Render(proj.Name))
+		if proj.Ticket != "" {
+			fmt.Print(dimmedText.Render(" • " + proj.Ticket))
+		}
+		fmt.Println()
 
 		item := tdb.TodoWhat(proj.Id)
+		today := time.Now().Format(time.DateOnly)
 
 		if item == nil {
 			fmt.Println(greenTextStyle.Render("All done!"))
 		} else {
-			fmt.Printf("%s\n\n%s\n\n", boldText.Render("To do:"), item.Task)
+			fmt.Printf("%s\n\n%s%s\n\n", boldText.Render("To do:"), item.Task, dueLabel(item, today))
+			if item.Notes != "" {
+				for _, line := range strings.Split(item.Notes, "\n") {
+					fmt.Println(dimmedText.Render(line))
+				}
+				fmt.Println()
+			}
+		}
+
+		due := []base.Todo{}
+		err = tdb.FilterTodos(base.TodoFilter{ProjectId: proj.Id, Pending: true, DueBy: today}, func(p base.Project, t base.Todo) {
+			if item == nil || t.Id != item.Id {
+				due = append(due, t)
+			}
+		})
+		ExitOnError(err, 1)
+
+		if len(due) > 0 {
+			slices.SortStableFunc(due, func(a, b base.Todo) int {
+				return strings.Compare(a.DueAt.String, b.DueAt.String)
+			})
+			fmt.Printf("%s\n\n", boldText.Render("Due:"))
+			for _, t := range due {
+				fmt.Printf("  - %s%s\n", t.Task, dueLabel(&t, today))
+			}
+			fmt.Println()
 		}
 
 		if te != nil && te.ProjectId == proj.Id && te.Action == base.TimesheetActionStart {
@@ -89,4 +131,52 @@ the branch name.`,
 
 func init() {
 	RootCmd.AddCommand(whatCmd)
+	whatCmd.Flags().BoolP("json", "j", false, "Print the output in JSON format")
+}
+
+func printWhatJSON(tdb *base.TodoDb, proj *base.Project, te *base.TimeEntry) {
+	type stashItem struct {
+		Ref    string `json:"ref"`
+		Date   string `json:"date"`
+		ItemId int    `json:"item_id"`
+		Task   string `json:"task"`
+		Branch string `json:"branch"`
+	}
+
+	stash, err := shell.GetStashItems()
+	ExitOnError(err, 1)
+
+	stashItems := []stashItem{}
+
+	if len(stash) > 0 {
+		ids := make([]int, 0, len(stash))
+		for k := range stash {
+			ids = append(ids, k)
+		}
+
+		ibs, err := tdb.GetItemsAndBranch(ids)
+		ExitOnError(err, 1)
+
+		for _, ib := range ibs {
+			stashItems = append(stashItems, stashItem{
+				Ref:    stash[ib.ItemId].Ref,
+				Date:   stash[ib.ItemId].Date,
+				ItemId: ib.ItemId,
+				Task:   ib.ItemName,
+				Branch: ib.BranchName,
+			})
+		}
+	}
+
+	printJSON(struct {
+		Project *base.Project `json:"project"`
+		Todo    *base.Todo    `json:"todo"`
+		Timer   jsonTimer     `json:"timer"`
+		Stash   []stashItem   `json:"stash"`
+	}{
+		Project: proj,
+		Todo:    tdb.TodoWhat(proj.Id),
+		Timer:   timerState(tdb, te, proj.Id),
+		Stash:   stashItems,
+	})
 }
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"regexp"
+	"runtime"
 	"strings"
 )
 
@@ -50,6 +52,59 @@ func GetDirEnv() (*DirEnv, error) {
 	}, nil
 }
 
+// GitConfig reads the value of the git config key,
+// and tells if the key was found
+func GitConfig(key string) (string, bool) {
+	out, err := exec.Command("git", "config", "--get", key).Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(out)), true
+}
+
+// GitConfigAll reads all values of the multi-valued git config key
+func GitConfigAll(key string) []string {
+	out, err := exec.Command("git", "config", "--get-all", key).Output()
+	if err != nil {
+		return []string{}
+	}
+	return strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+}
+
+// GitConfigSection reads all keys of the git config section, and returns
+// them by their names within the section. Git lowercases the names.
+func GitConfigSection(section string) map[string]string {
+	out, err := exec.Command("git", "config", "--get-regexp", "^"+regexp.QuoteMeta(section)+`\.`).Output()
+	if err != nil {
+		return map[string]string{}
+	}
+	return parseConfigSection(string(out), section)
+}
+
+// parseConfigSection parses the output of "git config --get-regexp",
+// where every line is a key followed by a space and the value
+func parseConfigSection(content, section string) map[string]string {
+	result := map[string]string{}
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		name, value, _ := strings.Cut(line, " ")
+		name, ok := strings.CutPrefix(name, section+".")
+		if ok && name != "" {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// GitConfigPath reads the git config key as a path, where the leading ~
+// is expanded, and tells if the key was found
+func GitConfigPath(key string) (string, bool) {
+	out, err := exec.Command("git", "config", "--type=path", "--get", key).Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(out)), true
+}
+
 func ListBranches() ([]string, error) {
 	out, err := exec.Command("git", "--no-pager", "branch", "--format=%(refname:short)").Output()
 	if err != nil {
@@ -58,6 +113,15 @@ func ListBranches() ([]string, error) {
 	return strings.Split(strings.TrimSpace(string(out)), "\n"), nil
 }
 
+// CurrentBranch returns the branch checked out in the repository in the dir
+func CurrentBranch(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func CheckoutBranch(name, from string, create bool) error {
 	args := []string{"checkout"}
 	if create {
@@ -67,10 +131,33 @@ func CheckoutBranch(name, from string, create bool) error {
 	if from != "" {
 		args = append(args, from)
 	}
-	_, err := exec.Command("git", args...).Output()
+	_, err := gitOutput(args...)
 	return err
 }
 
+// HasChanges tells if there are any uncommitted changes,
+// including untracked files
+func HasChanges() (bool, error) {
+	out, err := gitOutput("status", "--porcelain")
+	return strings.TrimSpace(out) != "", err
+}
+
+// OpenURL opens the URL in the default browser
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	return cmd.Start()
+}
+
 func GitStatus() {
 	cmd := exec.Command("git", "status")
 	cmd.Env = os.Environ()
@@ -0,0 +1,40 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseConfigSection(t *testing.T) {
+	content := "gitodo.keys.up w\ngitodo.keys.pushup ctrl+w ctrl+up\ngitodo.keys.mark space\ngitodo.keysx.down s\n"
+	expected := map[string]string{
+		"up":     "w",
+		"pushup": "ctrl+w ctrl+up",
+		"mark":   "space",
+	}
+
+	got := parseConfigSection(content, "gitodo.keys")
+	if !maps.Equal(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := parseConfigSection("", "gitodo.keys"); len(got) != 0 {
+		t.Errorf("expected no keys, got %v", got)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var regDue = regexp.MustCompile(`(?i)(?:^|\s)due:(\S+)`)
+
+// ExtractDue finds the due:date token in the first line of the text, and
+// returns the text without the token, the due date in YYYY-MM-DD format,
+// and whether a valid token was found. The date can be given as YYYY-MM-DD,
+// today, tomorrow or a day of the week (i.e. fri), which is the first such
+// day starting from today. For due:none the date is empty.
+func ExtractDue(text string, now time.Time) (string, string, bool) {
+	due := ""
+	text, found := extractToken(text, regDue, func(value string) bool {
+		d, ok := parseDue(value, now)
+		if ok {
+			due = d
+		}
+		return ok
+	})
+	return text, due, found
+}
+
+// AppendDue appends the due:date token to the first line of the text,
+// unless the due date is empty
+func AppendDue(text, due string) string {
+	if due == "" {
+		return text
+	}
+	return appendToken(text, "due:"+due)
+}
+
+// extractToken removes the tokens matched by the regexp from the first line
+// of the text if the parse function accepts their values, and tells if any
+// of them were accepted. The regexp must capture the value of the token.
+func extractToken(text string, reg *regexp.Regexp, parse func(value string) bool) (string, bool) {
+	first, rest, multiline := strings.Cut(text, "\n")
+	found := false
+
+	first = reg.ReplaceAllStringFunc(first, func(token string) string {
+		m := reg.FindStringSubmatch(token)
+		if !parse(m[1]) {
+			return token
+		}
+		found = true
+		return ""
+	})
+
+	if !found {
+		return text, false
+	}
+
+	first = strings.Join(strings.Fields(first), " ")
+	if multiline {
+		return first + "\n" + rest, true
+	}
+	return first, true
+}
+
+// appendToken appends the token to the first line of the text
+func appendToken(text, token string) string {
+	first, rest, multiline := strings.Cut(text, "\n")
+	first += " " + token
+
+	if multiline {
+		return first + "\n" + rest
+	}
+	return first
+}
+
+// parseDue parses the value of the due:date token
+func parseDue(s string, now time.Time) (string, bool) {
+	s = strings.ToLower(s)
+
+	switch s {
+	case "none":
+		return "", true
+	case "today":
+		return now.Format(time.DateOnly), true
+	case "tomorrow", "tom":
+		return now.AddDate(0, 0, 1).Format(time.DateOnly), true
+	}
+
+	if len(s) >= 3 {
+		for i := range 7 {
+			d := now.AddDate(0, 0, i)
+			if strings.HasPrefix(strings.ToLower(d.Weekday().String()), s) {
+				return d.Format(time.DateOnly), true
+			}
+		}
+	}
+
+	if d, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return d.Format(time.DateOnly), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractDue(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		text, expected, due string
+		found               bool
+	}{
+		{"No due date", "No due date", "", false},
+		{"Release due:2026-10-20", "Release", "2026-10-20", true},
+		{"Release DUE:fri now", "Release now", "2026-10-16", true},
+		{"Release due:wednesday", "Release", "2026-10-14", true},
+		{"Release due:tue", "Release", "2026-10-20", true},
+		{"due:today Release", "Release", "2026-10-14", true},
+		{"Release due:tomorrow\ndue:fri in notes", "Release\ndue:fri in notes", "2026-10-15", true},
+		{"Release due:none", "Release", "", true},
+		{"Release due:soon", "Release due:soon", "", false},
+		{"Release overdue:fri", "Release overdue:fri", "", false},
+	}
+
+	for _, test := range tests {
+		text, due, found := ExtractDue(test.text, now)
+		if text != test.expected || due != test.due || found != test.found {
+			t.Errorf("%q: expected %q, %q, %v, got %q, %q, %v",
+				test.text, test.expected, test.due, test.found, text, due, found)
+		}
+	}
+
+	text := AppendDue("Release\nnotes", "2026-10-20")
+	if text != "Release due:2026-10-20\nnotes" {
+		t.Errorf("unexpected text %q", text)
+	}
+	if text, due, _ := ExtractDue(text, now); text != "Release\nnotes" || due != "2026-10-20" {
+		t.Errorf("round trip failed, got %q and %q", text, due)
+	}
+}
+
+func TestExtractEstimate(t *testing.T) {
+	tests := []struct {
+		text, expected string
+		estimate       int
+		found          bool
+	}{
+		{"No estimate", "No estimate", 0, false},
+		{"Refactor est:2h", "Refactor", 7200, true},
+		{"Refactor EST:1h30m now", "Refactor now", 5400, true},
+		{"est:1.5 Refactor", "Refactor", 5400, true},
+		{"Refactor est:45m due:fri", "Refactor due:fri", 2700, true},
+		{"Refactor est:none", "Refactor", 0, true},
+		{"Refactor est:10s", "Refactor est:10s", 0, false},
+		{"Refactor est:lots", "Refactor est:lots", 0, false},
+	}
+
+	for _, test := range tests {
+		text, estimate, found := ExtractEstimate(test.text)
+		if text != test.expected || estimate != test.estimate || found != test.found {
+			t.Errorf("%q: expected %q, %d, %v, got %q, %d, %v",
+				test.text, test.expected, test.estimate, test.found, text, estimate, found)
+		}
+	}
+
+	for _, s := range []int{3600, 5400, 2700} {
+		if _, estimate, _ := ExtractEstimate(AppendEstimate("Refactor", s)); estimate != s {
+			t.Errorf("round trip of %d failed, got %d", s, estimate)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var regEstimate = regexp.MustCompile(`(?i)(?:^|\s)est:(\S+)`)
+
+// ExtractEstimate finds the est:duration token in the first line of the text,
+// and returns the text without the token, the estimate in seconds, and whether
+// a valid token was found. See ParseEstimate for the format of the duration.
+func ExtractEstimate(text string) (string, int, bool) {
+	estimate := 0
+	text, found := extractToken(text, regEstimate, func(value string) bool {
+		s, ok := ParseEstimate(value)
+		if ok {
+			estimate = s
+		}
+		return ok
+	})
+	return text, estimate, found
+}
+
+// AppendEstimate appends the est:duration token to the first line
+// of the text, unless the estimate is zero
+func AppendEstimate(text string, seconds int) string {
+	if seconds <= 0 {
+		return text
+	}
+	return appendToken(text, "est:"+formatEstimate(seconds))
+}
+
+// ParseEstimate parses the estimate given in hours and minutes (i.e. 2h, 45m,
+// 1h30m or 1.5h) or as a number of hours, and returns it in seconds. The
+// estimate "none" is parsed as zero.
+func ParseEstimate(s string) (int, bool) {
+	s = strings.ToLower(s)
+
+	if s == "none" {
+		return 0, true
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		s += "h"
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Minute {
+		return 0, false
+	}
+
+	return int(d.Seconds()), true
+}
+
+// formatEstimate formats the seconds so that ParseEstimate reads them back
+func formatEstimate(seconds int) string {
+	d := (time.Duration(seconds) * time.Second).Round(time.Minute)
+	s := strings.TrimSuffix(d.String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		return strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Marker is a TODO, FIXME or XXX comment found in a file of the repository,
+// where the file is relative to the root of the repository
+type Marker struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+// Source returns the file:line reference of the marker
+func (m Marker) Source() string {
+	return fmt.Sprintf("%s:%d", m.File, m.Line)
+}
+
+// files larger than this are not scanned
+const maxScanSize = 1 << 20
+
+// markers must follow the start of a comment, i.e. // TODO: text
+var regMarker = regexp.MustCompile(`(?://|#|/\*|\*|--|;|<!--)\s*(TODO|FIXME|XXX)\b(?:\([^)]*\))?:?(.*)`)
+
+// parseMarker finds the marker in the line and returns its kind and text
+func parseMarker(line string) (kind, text string, ok bool) {
+	m := regMarker.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+
+	text = strings.TrimSpace(m[2])
+	for _, end := range []string{"*/", "-->", "#}", "%>"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, end))
+	}
+
+	return m[1], text, true
+}
+
+// ScanMarkers reads the markers from the files of the repository
+// in the given directory. Binary and large files are skipped.
+func ScanMarkers(root string, files []string) ([]Marker, error) {
+	markers := []Marker{}
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(root, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// deleted, but still in the diff
+				continue
+			}
+			return nil, err
+		}
+
+		if len(content) > maxScanSize || bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0 {
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxScanSize)
+
+		for line := 1; scanner.Scan(); line++ {
+			if kind, text, ok := parseMarker(scanner.Text()); ok {
+				markers = append(markers, Marker{File: file, Line: line, Kind: kind, Text: text})
+			}
+		}
+	}
+
+	return markers, nil
+}
+
+// FindMarker finds the line of the marker with the given text in the file
+// of the repository in the given directory, or returns 0 if it's not found.
+// Markers without text can't be told apart, so they are looked up only at
+// the line where they were found before.
+func FindMarker(root, file, text string, line int) int {
+	markers, err := ScanMarkers(root, []string{file})
+	if err != nil {
+		return 0
+	}
+
+	for _, m := range markers {
+		if m.Text == text && (text != "" || m.Line == line) {
+			return m.Line
+		}
+	}
+
+	return 0
+}
+
+// Key identifies the marker among the others. Markers without text
+// are identified by their line, since there can be more of them.
+func (m Marker) Key() string {
+	if m.Text == "" {
+		return m.Source()
+	}
+	return m.File + "\x00" + m.Text
+}
+
+// TrackedFiles lists the files tracked by git in the repository
+func TrackedFiles(root string) ([]string, error) {
+	out, err := exec.Command("git", "-C", root, "ls-files").Output()
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(string(out)), nil
+}
+
+// ChangedFiles lists the files changed on the current branch since it
+// diverged from the base branch, along with the uncommitted changes
+// and untracked files in the repository
+func ChangedFiles(root, base string) ([]string, error) {
+	files := []string{}
+
+	for _, args := range [][]string{
+		{"diff", "--name-only", base + "...HEAD"},
+		{"diff", "--name-only", "HEAD"},
+		{"ls-files", "--others", "--exclude-standard"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", root}, args...)...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("Could not list the changes since %q.", base)
+		}
+		files = append(files, splitLines(string(out))...)
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// DefaultBranch returns the branch that other branches start from, as set
+// with the gitodo.baseBranch git config key, or either main or master if
+// one of them exists
+func DefaultBranch() string {
+	if b, ok := GitConfig("gitodo.baseBranch"); ok && b != "" {
+		return b
+	}
+
+	branches, _ := ListBranches()
+	for _, b := range []string{"main", "master"} {
+		if slices.Contains(branches, b) {
+			return b
+		}
+	}
+
+	return "main"
+}
+
+func splitLines(s string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseMarker(t *testing.T) {
+	tests := []struct {
+		line, kind, text string
+		ok               bool
+	}{
+		{"// TODO: handle the error", "TODO", "handle the error", true},
+		{"# FIXME(bob) flaky test", "FIXME", "flaky test", true},
+		{"/* XXX: remove this */", "XXX", "remove this", true},
+		{"<!-- TODO --> ", "TODO", "", true},
+		{"// TODOS are fine", "", "", false},
+		{"func todo() {}", "", "", false},
+		{`re := "TODO|FIXME"`, "", "", false},
+		{"x := 1 // XXX", "XXX", "", true},
+	}
+
+	for _, test := range tests {
+		kind, text, ok := parseMarker(test.line)
+		if kind != test.kind || text != test.text || ok != test.ok {
+			t.Errorf("%q: expected %q, %q, %v, got %q, %q, %v",
+				test.line, test.kind, test.text, test.ok, kind, text, ok)
+		}
+	}
+}
+
+func TestScanMarkers(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\n// TODO: write main\nfunc main() {}\n// TODO\n// FIXME\n"), 0644)
+	os.WriteFile(filepath.Join(root, "data.bin"), []byte("TODO\x00binary"), 0644)
+
+	markers, err := ScanMarkers(root, []string{"main.go", "data.bin", "deleted.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Marker{
+		{File: "main.go", Line: 3, Kind: "TODO", Text: "write main"},
+		{File: "main.go", Line: 5, Kind: "TODO"},
+		{File: "main.go", Line: 6, Kind: "FIXME"},
+	}
+	if !slices.Equal(markers, expected) {
+		t.Errorf("expected %v, got %v", expected, markers)
+	}
+
+	if line := FindMarker(root, "main.go", "write main", 1); line != 3 {
+		t.Errorf("expected the marker at line 3, got %d", line)
+	}
+	if line := FindMarker(root, "main.go", "something else", 3); line != 0 {
+		t.Errorf("expected no marker, got %d", line)
+	}
+	if line := FindMarker(root, "main.go", "", 6); line != 6 {
+		t.Errorf("expected the marker without text at line 6, got %d", line)
+	}
+	if line := FindMarker(root, "main.go", "", 4); line != 0 {
+		t.Errorf("expected no marker without text at line 4, got %d", line)
+	}
+
+	if markers[1].Key() == markers[2].Key() {
+		t.Errorf("expected different keys of markers without text, got %q", markers[1].Key())
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package shell
 
 import (
+	"errors"
+	"fmt"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -27,6 +29,8 @@ type StashItem struct {
 	Date, Ref string
 }
 
+// parseStashList reads the stashes of the items, where the latest
+// stash is kept if an item has more of them
 func parseStashList(content string) map[int]StashItem {
 	result := make(map[int]StashItem)
 	regName := regexp.MustCompile("gitodo_([0-9]+)")
@@ -36,6 +40,9 @@ func parseStashList(content string) map[int]StashItem {
 		m := regName.FindStringSubmatch(row)
 		if len(m) == 2 {
 			todoId, _ := strconv.Atoi(m[1])
+			if _, ok := result[todoId]; ok {
+				continue
+			}
 			date := regDate.FindStringSubmatch(row)
 			item := StashItem{Ref: "stash@{" + strconv.Itoa(i) + "}"}
 			if len(date) == 2 {
@@ -56,6 +63,15 @@ func GetStashItems() (map[int]StashItem, error) {
 	return parseStashList(string(revOutput)), nil
 }
 
+// GetRepoStashItems reads the item stashes of the repository in the dir
+func GetRepoStashItems(dir string) (map[int]StashItem, error) {
+	revOutput, err := exec.Command("git", "-C", dir, "--no-pager", "stash", "list", "--date=local").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseStashList(string(revOutput)), nil
+}
+
 func PushStash(todoId int) error {
 	_, err := exec.Command("git", "stash", "push", "-m", "gitodo_"+strconv.Itoa(todoId), "--include-untracked").Output()
 	return err
@@ -66,7 +82,151 @@ func PushStashNoItem() (string, error) {
 	return string(out), err
 }
 
+// PushSwitchStash stashes the changes before switching to another branch,
+// so that they can be restored once the branch is checked out again. The
+// message mentions the item if the id is not 0, but the stash is not linked
+// to the item, since an item can have only one stash.
+func PushSwitchStash(todoId int) error {
+	msg := "gitodo_switch"
+	if todoId > 0 {
+		msg += " #" + strconv.Itoa(todoId)
+	}
+	_, err := gitOutput("stash", "push", "-m", msg, "--include-untracked")
+	return err
+}
+
+// FindSwitchStash returns the latest stash pushed when switching away from
+// the branch, and tells if it was found
+func FindSwitchStash(stashes []StashEntry, branch string) (StashEntry, bool) {
+	for _, s := range stashes {
+		if s.Switch && s.Branch == branch {
+			return s, true
+		}
+	}
+	return StashEntry{}, false
+}
+
 func PopStash(stash string) error {
-	_, err := exec.Command("git", "stash", "pop", stash).Output()
+	_, err := gitOutput("stash", "pop", stash)
+	return err
+}
+
+// StashEntry is a stash of the repository, where the item id is set
+// if the stash was pushed for a to-do item
+type StashEntry struct {
+	Ref     string `json:"ref"`
+	Date    string `json:"date"`
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+	Branch  string `json:"branch"`
+	TodoId  int    `json:"item_id,omitempty"`
+	Switch  bool   `json:"switch"`
+}
+
+// stashListFormat prints the stash list as the selector with the date,
+// the commit hash and the message, separated by tabs
+const stashListFormat = "--format=%gd%x09%H%x09%gs"
+
+func parseStashEntries(content string) []StashEntry {
+	result := []StashEntry{}
+	regName := regexp.MustCompile("gitodo_([0-9]+)$")
+	regSwitch := regexp.MustCompile("gitodo_switch(?: #[0-9]+)?$")
+	regBranch := regexp.MustCompile("^(?:WIP on|On) ([^:]+):")
+
+	for _, row := range strings.Split(strings.TrimSpace(content), "\n") {
+		parts := strings.SplitN(row, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		entry := StashEntry{
+			Ref:     "stash@{" + strconv.Itoa(len(result)) + "}",
+			Hash:    parts[1],
+			Message: parts[2],
+		}
+		if date, ok := strings.CutPrefix(parts[0], "stash@{"); ok {
+			entry.Date = strings.TrimSuffix(date, "}")
+		}
+		if m := regName.FindStringSubmatch(parts[2]); len(m) == 2 {
+			entry.TodoId, _ = strconv.Atoi(m[1])
+		}
+		if m := regBranch.FindStringSubmatch(parts[2]); len(m) == 2 {
+			entry.Branch = m[1]
+		}
+		entry.Switch = regSwitch.MatchString(parts[2])
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// GetStashList reads all of the stashes of the repository, the latest first
+func GetStashList() ([]StashEntry, error) {
+	out, err := gitOutput("--no-pager", "stash", "list", "--date=local", stashListFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parseStashEntries(out), nil
+}
+
+// StashStat returns the diffstat of the stash, including untracked files
+func StashStat(ref string) (string, error) {
+	return gitOutput("--no-pager", "stash", "show", "--include-untracked", "--stat", ref)
+}
+
+// StashPatch returns the diff of the stash, including untracked files
+func StashPatch(ref string) (string, error) {
+	return gitOutput("--no-pager", "stash", "show", "--include-untracked", "--patch", ref)
+}
+
+// ApplyStash applies the changes of the stash, and keeps the stash
+func ApplyStash(ref string) error {
+	_, err := gitOutput("stash", "apply", ref)
 	return err
 }
+
+// DropStash deletes the stash
+func DropStash(ref string) error {
+	_, err := gitOutput("stash", "drop", ref)
+	return err
+}
+
+// LinkStash links the stash to the to-do item by storing it again with
+// the message of the item, which makes it the latest stash
+func LinkStash(ref string, todoId int) error {
+	hash, err := gitOutput("rev-parse", "--verify", ref)
+	if err != nil {
+		return err
+	}
+	hash = strings.TrimSpace(hash)
+
+	if _, err = gitOutput("stash", "drop", ref); err != nil {
+		return err
+	}
+
+	_, err = gitOutput("stash", "store", "-m", "gitodo_"+strconv.Itoa(todoId), hash)
+	if err != nil {
+		return fmt.Errorf("%w (the stash was dropped, restore it with \"git stash store %s\")", err, hash)
+	}
+
+	return nil
+}
+
+// gitOutput runs git with the arguments, and returns the error output
+// of git as the error if it fails, or the output if there is none
+// (i.e. merge conflicts are reported to the standard output)
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		msg := strings.TrimSpace(string(exitErr.Stderr))
+		if msg == "" {
+			msg = strings.TrimSpace(string(out))
+		}
+		if msg != "" {
+			return string(out), errors.New(msg)
+		}
+	}
+	return string(out), err
+}
@@ -18,13 +18,16 @@ package shell
 
 import (
 	"maps"
+	"slices"
 	"testing"
 )
 
 func TestParseStashList(t *testing.T) {
 	sample := `stash@{Tue Jan 09 10:11:12 2025}: WIP on master: 04fd51c update docs
 stash@{Tue Jan 14 19:13:06 2025}: On master: gitodo_7
-stash@{Tue Jan 18 10:11:12 2025}: WIP on master: 04fd51c update docs`
+stash@{Tue Jan 18 10:11:12 2025}: WIP on master: 04fd51c update docs
+stash@{Tue Jan 19 10:11:12 2025}: On master: gitodo_switch #7
+stash@{Tue Jan 20 10:11:12 2025}: On master: gitodo_7`
 
 	expected := map[int]StashItem{7: {Ref: "stash@{1}", Date: "Tue Jan 14 19:13:06 2025"}}
 	got := parseStashList(sample)
@@ -34,3 +37,43 @@ stash@{Tue Jan 18 10:11:12 2025}: WIP on master: 04fd51c update docs`
 		t.Errorf("maps not equal. got: %v", got)
 	}
 }
+
+func TestParseStashEntries(t *testing.T) {
+	sample := "stash@{Tue Jan 18 10:11:12 2025}\tb1\tWIP on master: 04fd51c update docs\n" +
+		"stash@{Tue Jan 14 19:13:06 2025}\ta7\tOn master: gitodo_7\n" +
+		"stash@{Tue Jan 12 09:10:00 2025}\tc3\tOn feat/x: gitodo_switch #3\n" +
+		"stash@{Tue Jan 10 08:00:00 2025}\td4\tOn feat/x: gitodo_switch\n"
+
+	expected := []StashEntry{
+		{Ref: "stash@{0}", Date: "Tue Jan 18 10:11:12 2025", Hash: "b1", Message: "WIP on master: 04fd51c update docs", Branch: "master"},
+		{Ref: "stash@{1}", Date: "Tue Jan 14 19:13:06 2025", Hash: "a7", Message: "On master: gitodo_7", Branch: "master", TodoId: 7},
+		{Ref: "stash@{2}", Date: "Tue Jan 12 09:10:00 2025", Hash: "c3", Message: "On feat/x: gitodo_switch #3", Branch: "feat/x", Switch: true},
+		{Ref: "stash@{3}", Date: "Tue Jan 10 08:00:00 2025", Hash: "d4", Message: "On feat/x: gitodo_switch", Branch: "feat/x", Switch: true},
+	}
+	got := parseStashEntries(sample)
+
+	if !slices.Equal(expected, got) {
+		t.Errorf("entries not equal. got: %v", got)
+	}
+
+	if got := parseStashEntries(""); len(got) != 0 {
+		t.Errorf("expected no entries, got: %v", got)
+	}
+}
+
+func TestFindSwitchStash(t *testing.T) {
+	stashes := []StashEntry{
+		{Ref: "stash@{0}", Branch: "main", TodoId: 2},
+		{Ref: "stash@{1}", Branch: "feat", Switch: true},
+		{Ref: "stash@{2}", Branch: "main", Switch: true},
+		{Ref: "stash@{3}", Branch: "main", Switch: true},
+	}
+
+	if s, ok := FindSwitchStash(stashes, "main"); !ok || s.Ref != "stash@{2}" {
+		t.Errorf("expected stash@{2}, got: %v %v", s, ok)
+	}
+
+	if s, ok := FindSwitchStash(stashes, "other"); ok {
+		t.Errorf("expected no stash, got: %v", s)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TaskListItem is a single item of a markdown task list, where the level
+// tells how deep the item is nested. New items added in the editor don't
+// have an id.
+type TaskListItem struct {
+	Id    int
+	Done  bool
+	Task  string
+	Level int
+}
+
+const taskListHeader = `# Edit the list, then save and close the file to apply the changes.
+#
+# - reorder the lines to change the order of the items
+# - tick the boxes with [x] to complete items, clear them to reopen them
+# - rewrite the text of the items, indent the notes with two spaces
+# - indent the items by two spaces to make them sub-items of the item above
+# - delete the lines to delete the items
+# - add new items as "- [ ] text" lines
+#
+# Do not change the <!-- #id --> markers at the end of the lines.
+# Lines starting with # are ignored.
+`
+
+var (
+	regTaskMarker = regexp.MustCompile(`\s*<!--\s*#([0-9]+)\s*-->\s*$`)
+	regTaskBox    = regexp.MustCompile(`^-\s*\[([ xX]?)\]\s?`)
+)
+
+// NewTaskListTmpFile creates a temporary file with the items
+// formatted as a markdown task list with hidden id markers
+func NewTaskListTmpFile(title string, items []TaskListItem) (*TmpFile, error) {
+	return NewTmpFileString(FormatTaskList(title, items))
+}
+
+// FormatTaskList formats the items as a markdown task list
+func FormatTaskList(title string, items []TaskListItem) string {
+	builder := strings.Builder{}
+	builder.WriteString("# ")
+	builder.WriteString(title)
+	builder.WriteString("\n#\n")
+	builder.WriteString(taskListHeader)
+
+	for _, item := range items {
+		indent := strings.Repeat("  ", item.Level)
+		builder.WriteString(indent)
+		if item.Done {
+			builder.WriteString("- [x] ")
+		} else {
+			builder.WriteString("- [ ] ")
+		}
+
+		first, rest, multiline := strings.Cut(item.Task, "\n")
+		builder.WriteString(first)
+		builder.WriteString(fmt.Sprintf(" <!-- #%d -->\n", item.Id))
+
+		if multiline {
+			for _, line := range strings.Split(rest, "\n") {
+				// escape the notes that would be read as sub-items
+				if isTaskLine(line) {
+					line = `\` + line
+				}
+				builder.WriteString(indent + "  ")
+				builder.WriteString(line)
+				builder.WriteRune('\n')
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// ReadTaskList reads the markdown task list from the file. Indented lines
+// with a check box start sub-items, which are nested at most one level
+// deeper than the item above them. Other indented lines are the notes.
+func (tf *TmpFile) ReadTaskList() ([]TaskListItem, error) {
+	file, err := os.Open(tf.path)
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	items := []TaskListItem{}
+	var current *TaskListItem
+	lines := []string{}
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Task = strings.TrimSpace(strings.Join(lines, "\n"))
+		if current.Task != "" {
+			items = append(items, *current)
+		}
+		current = nil
+		lines = lines[:0]
+	}
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		txt := scanner.Text()
+		indent := itemIndent(txt)
+
+		switch {
+		case txt != "" && txt[0] == '#':
+			continue
+		case indent == 0 || (indent > 0 && isTaskLine(txt)):
+			flush()
+			current = &TaskListItem{}
+			if len(items) > 0 {
+				current.Level = min(indent/2, items[len(items)-1].Level+1)
+			}
+			txt = strings.TrimLeft(txt, " \t")
+
+			if m := regTaskMarker.FindStringSubmatch(txt); len(m) == 2 {
+				current.Id, _ = strconv.Atoi(m[1])
+				txt = txt[:len(txt)-len(m[0])]
+			}
+
+			if m := regTaskBox.FindStringSubmatch(txt); len(m) == 2 {
+				current.Done = m[1] == "x" || m[1] == "X"
+				txt = txt[len(m[0]):]
+			} else {
+				txt = strings.TrimSpace(txt[1:])
+			}
+
+			lines = append(lines, txt)
+		case current != nil:
+			txt = trimIndent(txt, current.Level*2+2)
+			if strings.HasPrefix(txt, `\`) && isTaskLine(txt[1:]) {
+				txt = txt[1:]
+			}
+			lines = append(lines, txt)
+		}
+	}
+
+	flush()
+
+	return items, scanner.Err()
+}
+
+// isTaskLine tells if the line, regardless of its indentation,
+// starts with a hyphen and a check box
+func isTaskLine(line string) bool {
+	return regTaskBox.MatchString(strings.TrimLeft(line, " \t"))
+}
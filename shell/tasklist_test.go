@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTaskListRoundTrip(t *testing.T) {
+	items := []TaskListItem{
+		{Id: 1, Done: true, Task: "First"},
+		{Id: 2, Task: "Second\n- with a hyphen\n# and a hash\n\nand an empty line"},
+		{Id: 3, Task: "Third <!-- #7 -->"},
+		{Id: 4, Task: "Sub-item\n- [ ]with notes\n  - [x] indented", Level: 1},
+		{Id: 5, Done: true, Task: "Deeper", Level: 2},
+		{Id: 6, Task: "Back up", Level: 1},
+	}
+
+	tmp, err := NewTaskListTmpFile("main", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Delete()
+
+	result, err := tmp.ReadTaskList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(items, result) {
+		t.Errorf("not equal. expected %v, got %v", items, result)
+	}
+}
+
+func TestReadTaskList(t *testing.T) {
+	tmp, err := NewTmpFileString(`# comment
+- [x] Moved up <!-- #3 -->
+-[ ]No space   <!--#1-->
+  continued
+- plain new item
+- [X] new and done
+
+- [ ]  <!-- #2 -->
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Delete()
+
+	expected := []TaskListItem{
+		{Id: 3, Done: true, Task: "Moved up"},
+		{Id: 1, Task: "No space\ncontinued"},
+		{Task: "plain new item"},
+		{Done: true, Task: "new and done"},
+	}
+
+	result, err := tmp.ReadTaskList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(expected, result) {
+		t.Errorf("not equal. expected %v, got %v", expected, result)
+	}
+}
+
+func TestReadTaskListNested(t *testing.T) {
+	tmp, err := NewTmpFileString(`- [ ] Parent <!-- #1 -->
+  note of the parent
+  - [ ] Child <!-- #2 -->
+    note of the child
+  - not an item
+        - [ ] Too deep
+- [ ] Top <!-- #3 -->
+	- [x] Tab indented
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Delete()
+
+	expected := []TaskListItem{
+		{Id: 1, Task: "Parent\nnote of the parent"},
+		{Id: 2, Task: "Child\nnote of the child\n- not an item", Level: 1},
+		{Task: "Too deep", Level: 2},
+		{Id: 3, Task: "Top"},
+		{Done: true, Task: "Tab indented", Level: 1},
+	}
+
+	result, err := tmp.ReadTaskList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(expected, result) {
+		t.Errorf("not equal. expected %v, got %v", expected, result)
+	}
+}
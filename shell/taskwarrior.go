@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// statuses of Taskwarrior tasks
+const (
+	TaskwarriorPending   = "pending"
+	TaskwarriorCompleted = "completed"
+	TaskwarriorDeleted   = "deleted"
+	TaskwarriorWaiting   = "waiting"
+	TaskwarriorRecurring = "recurring"
+)
+
+// TaskwarriorTimeLayout is the layout of the dates in Taskwarrior JSON, in UTC
+const TaskwarriorTimeLayout = "20060102T150405Z"
+
+// TaskwarriorTask is a task in the JSON format of "task export" and "task import"
+type TaskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Project     string                  `json:"project,omitempty"`
+	Entry       string                  `json:"entry,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Annotations []TaskwarriorAnnotation `json:"annotations,omitempty"`
+}
+
+type TaskwarriorAnnotation struct {
+	Entry       string `json:"entry,omitempty"`
+	Description string `json:"description"`
+}
+
+// ParseTaskwarrior reads the tasks exported from Taskwarrior, either as
+// a JSON array, or as one JSON object per line like in older versions
+func ParseTaskwarrior(r io.Reader) ([]TaskwarriorTask, error) {
+	reader := bufio.NewReader(r)
+	tasks := []TaskwarriorTask{}
+
+	for {
+		b, err := reader.Peek(1)
+		if err == io.EOF {
+			return tasks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n', ',':
+			reader.ReadByte()
+			continue
+		case '[':
+			return tasks, json.NewDecoder(reader).Decode(&tasks)
+		}
+
+		task := TaskwarriorTask{}
+		decoder := json.NewDecoder(reader)
+		if err := decoder.Decode(&task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+		reader = bufio.NewReader(io.MultiReader(decoder.Buffered(), reader))
+	}
+}
+
+// FormatTaskwarrior formats the tasks as a JSON array for "task import"
+func FormatTaskwarrior(tasks []TaskwarriorTask) ([]byte, error) {
+	return json.MarshalIndent(tasks, "", "  ")
+}
+
+// TaskwarriorTime formats the time for Taskwarrior JSON
+func TaskwarriorTime(t time.Time) string {
+	return t.UTC().Format(TaskwarriorTimeLayout)
+}
+
+// ParseTaskwarriorTime parses the time from Taskwarrior JSON into local time
+func ParseTaskwarriorTime(s string) (time.Time, error) {
+	t, err := time.Parse(TaskwarriorTimeLayout, s)
+	return t.Local(), err
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTaskwarrior(t *testing.T) {
+	expected := []TaskwarriorTask{
+		{UUID: "a", Description: "First", Status: TaskwarriorPending, Project: "gitodo/main", Tags: []string{"code"}},
+		{UUID: "b", Description: "Second", Status: TaskwarriorCompleted, End: "20261005T100000Z",
+			Annotations: []TaskwarriorAnnotation{{Entry: "20261004T100000Z", Description: "a note"}}},
+	}
+
+	inputs := map[string]string{
+		"array": `[
+{"uuid":"a","description":"First","status":"pending","project":"gitodo/main","tags":["code"],"urgency":1.8},
+{"uuid":"b","description":"Second","status":"completed","end":"20261005T100000Z","annotations":[{"entry":"20261004T100000Z","description":"a note"}]}
+]`,
+		"lines": `{"uuid":"a","description":"First","status":"pending","project":"gitodo/main","tags":["code"]}
+{"uuid":"b","description":"Second","status":"completed","end":"20261005T100000Z","annotations":[{"entry":"20261004T100000Z","description":"a note"}]}
+`,
+	}
+
+	for name, input := range inputs {
+		tasks, err := ParseTaskwarrior(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(expected, tasks) {
+			t.Errorf("%s: not equal.\nexpected %v\ngot      %v", name, expected, tasks)
+		}
+	}
+
+	if _, err := ParseTaskwarrior(strings.NewReader(`{"uuid": `)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestTaskwarriorTime(t *testing.T) {
+	local := time.Date(2026, 10, 5, 12, 30, 0, 0, time.Local)
+	s := TaskwarriorTime(local)
+
+	parsed, err := ParseTaskwarriorTime(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(local) {
+		t.Errorf("expected %v, got %v", local, parsed)
+	}
+}
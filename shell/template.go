@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// TemplateSection is a named list of items read from a templates file
+type TemplateSection struct {
+	Name, Branches, Items string
+}
+
+// ReadTemplatesFile reads the templates from the file, where every template
+// starts with its name in square brackets, optionally followed by the patterns
+// of the branch names, and the items are written below it in the same format
+// as in the editor, i.e.:
+//
+//	[release] release/* hotfix/*
+//	- update the changelog
+//	- bump the version
+func ReadTemplatesFile(path string) ([]TemplateSection, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sections := []TemplateSection{}
+	builder := strings.Builder{}
+	scanner := bufio.NewScanner(file)
+
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Items = strings.TrimSpace(builder.String())
+		}
+		builder.Reset()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "[") {
+			if name, branches, ok := strings.Cut(line[1:], "]"); ok && strings.TrimSpace(name) != "" {
+				flush()
+				sections = append(sections, TemplateSection{
+					Name:     strings.TrimSpace(name),
+					Branches: strings.Join(strings.Fields(branches), " "),
+				})
+				continue
+			}
+		}
+
+		builder.WriteString(line)
+		builder.WriteRune('\n')
+	}
+
+	flush()
+
+	return sections, scanner.Err()
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReadTemplatesFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "templates")
+	content := `ignored line
+[release]   release/*   hotfix/*
+- update the changelog
+  - check the links
+
+[review]
+- self review
+[not a template
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sections, err := ReadTemplatesFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []TemplateSection{
+		{Name: "release", Branches: "release/* hotfix/*", Items: "- update the changelog\n  - check the links"},
+		{Name: "review", Items: "- self review\n[not a template"},
+	}
+
+	if !slices.Equal(sections, expected) {
+		t.Errorf("expected %v, got %v", expected, sections)
+	}
+}
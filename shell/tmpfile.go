@@ -19,9 +19,11 @@ package shell
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -31,9 +33,18 @@ type TmpFile struct {
 	path string
 }
 
+// Item is an item read from the file, where the level tells
+// how deep the item is nested under the items before it
+type Item struct {
+	Task     string
+	Level    int
+	Due      string
+	Estimate int
+}
+
 func NewItemsTmpFile() (*TmpFile, error) {
 	return NewTmpFileString(`# Start a line with a hyphen (-) to indicate a new item.
-# Comments like this are ignored.
+# Indent the hyphen by two spaces per level for sub-items, set due dates with due:fri and estimates with est:2h.
 - `)
 }
 
@@ -110,8 +121,8 @@ func (tf *TmpFile) ReadAll() string {
 	}
 }
 
-func (tf *TmpFile) ReadItems() ([]string, error) {
-	items := []string{}
+// ReadItems reads the items from the file, see ParseItems
+func (tf *TmpFile) ReadItems() ([]Item, error) {
 	file, err := os.Open(tf.path)
 
 	if err != nil {
@@ -119,44 +130,98 @@ func (tf *TmpFile) ReadItems() ([]string, error) {
 	}
 	defer file.Close()
 
+	return ParseItems(file)
+}
+
+// ParseItems reads the items from the reader. Items start with a hyphen,
+// and the hyphens indented by two or more spaces (or tabs) start sub-items.
+// Lines starting with # are ignored. Due dates are read from the due:date
+// tokens, see ExtractDue, and estimates from the est:duration tokens, see
+// ExtractEstimate.
+func ParseItems(r io.Reader) ([]Item, error) {
+	items := []Item{}
 	builder := strings.Builder{}
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
+	level, width := 0, 0
+
+	now := time.Now()
 
-	var txt, item string
+	flush := func() {
+		item, due, _ := ExtractDue(strings.TrimSpace(builder.String()), now)
+		item, estimate, _ := ExtractEstimate(item)
+		if item != "" {
+			items = append(items, Item{Task: item, Level: level, Due: due, Estimate: estimate})
+		}
+		builder.Reset()
+	}
+
+	var txt string
 	for scanner.Scan() {
 		txt = scanner.Text()
+		indent := itemIndent(txt)
+
 		switch {
 		case txt != "" && txt[0] == '#':
 			continue
-		case (txt != "" && txt[0] == '-') && builder.Len() > 0:
-			item = strings.TrimSpace(builder.String())
-			if item != "" {
-				items = append(items, item)
-			}
-			builder.Reset()
-			fallthrough
-		case txt != "" && txt[0] == '-':
+		case indent >= 0:
+			flush()
+			level, width = indent/2, indent
 			builder.WriteString(strings.TrimLeftFunc(
 				txt, func(r rune) bool { return r == '-' || unicode.IsSpace(r) },
 			))
 			builder.WriteRune('\n')
+		case width > 0:
+			// remove the indentation of the sub-item from its text
+			builder.WriteString(trimIndent(txt, width+2))
+			builder.WriteRune('\n')
 		default:
 			builder.WriteString(txt)
 			builder.WriteRune('\n')
 		}
 	}
 
-	if builder.Len() > 0 {
-		item = strings.TrimSpace(builder.String())
-	} else {
-		item = ""
-	}
+	flush()
+
+	return items, scanner.Err()
+}
 
-	if item != "" {
-		items = append(items, item)
+// trimIndent removes up to the given width of indentation from the line
+func trimIndent(line string, width int) string {
+	for width > 0 && line != "" {
+		switch line[0] {
+		case ' ':
+			width--
+		case '\t':
+			width -= 2
+		default:
+			return line
+		}
+		line = line[1:]
 	}
+	return line
+}
 
-	return items, nil
+// itemIndent returns the width of the indentation before the hyphen
+// that starts an item, or -1 if the line doesn't start an item.
+// Tabs count as two spaces, and a single space is not an indentation.
+func itemIndent(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 2
+		case '-':
+			if width == 1 {
+				return -1
+			}
+			return width
+		default:
+			return -1
+		}
+	}
+	return -1
 }
 
 func (tf *TmpFile) Path() string {
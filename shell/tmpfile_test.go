@@ -16,7 +16,10 @@ limitations under the License.
 
 package shell
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestReadItems(t *testing.T) {
 	tmp, err := NewTmpFileString(`#ignored
@@ -52,12 +55,44 @@ func TestReadItems(t *testing.T) {
 	}
 
 	for i := 0; i < len(expected); i++ {
-		if expected[i] != result[i] {
-			t.Errorf("not equal. expected %s, got %s", expected[i], result[i])
+		if expected[i] != result[i].Task || result[i].Level != 0 {
+			t.Errorf("not equal. expected %s, got %v", expected[i], result[i])
 		}
 	}
 }
 
+func TestReadItemsNested(t *testing.T) {
+	tmp, err := NewTmpFileString(`- Parent
+  - Child
+    with more text
+    - Grandchild
+	- Tabbed child
+- Another parent
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Delete()
+
+	expected := []Item{
+		{Task: "Parent"},
+		{Task: "Child\nwith more text", Level: 1},
+		{Task: "Grandchild", Level: 2},
+		{Task: "Tabbed child", Level: 1},
+		{Task: "Another parent"},
+	}
+
+	result, err := tmp.ReadItems()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(expected, result) {
+		t.Errorf("not equal. expected %v, got %v", expected, result)
+	}
+}
+
 func TestReadItemsEmpty(t *testing.T) {
 	tmp, err := NewTmpFileString(`#ignored
 # also ignored
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TodoTxtItem is a single line of a todo.txt file. The task is the
+// description without the completion mark, priority and dates, while the
+// +project and @context tags are kept in it and also listed separately.
+// Dates are in YYYY-MM-DD format, or empty if not given.
+type TodoTxtItem struct {
+	Done        bool
+	Priority    string
+	CompletedAt string
+	CreatedAt   string
+	Task        string
+	Projects    []string
+	Contexts    []string
+}
+
+var regTodoTxtPriority = regexp.MustCompile(`^\([A-Z]\)$`)
+
+// ParseTodoTxt reads the items from the todo.txt formatted input,
+// skipping the empty lines
+func ParseTodoTxt(r io.Reader) ([]TodoTxtItem, error) {
+	items := []TodoTxtItem{}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if item, ok := parseTodoTxtLine(scanner.Text()); ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, scanner.Err()
+}
+
+// parseTodoTxtLine parses a single line of a todo.txt file
+func parseTodoTxtLine(line string) (TodoTxtItem, bool) {
+	fields := strings.Fields(line)
+	item := TodoTxtItem{}
+
+	if len(fields) > 0 && fields[0] == "x" {
+		item.Done = true
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 && regTodoTxtPriority.MatchString(fields[0]) {
+		item.Priority = fields[0][1:2]
+		fields = fields[1:]
+	}
+
+	// a completed item can have both dates, the completion date first
+	if len(fields) > 0 && isTodoTxtDate(fields[0]) {
+		item.CreatedAt = fields[0]
+		fields = fields[1:]
+
+		if item.Done && len(fields) > 0 && isTodoTxtDate(fields[0]) {
+			item.CompletedAt = item.CreatedAt
+			item.CreatedAt = fields[0]
+			fields = fields[1:]
+		} else if item.Done {
+			item.CompletedAt = item.CreatedAt
+			item.CreatedAt = ""
+		}
+	}
+
+	for _, f := range fields {
+		switch {
+		case len(f) > 1 && f[0] == '+':
+			item.Projects = append(item.Projects, f[1:])
+		case len(f) > 1 && f[0] == '@':
+			item.Contexts = append(item.Contexts, f[1:])
+		}
+	}
+
+	item.Task = strings.Join(fields, " ")
+	return item, item.Task != ""
+}
+
+// isTodoTxtDate tells if the field is a date in YYYY-MM-DD format
+func isTodoTxtDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
+
+// FormatTodoTxt formats the items as lines of a todo.txt file. The
+// projects and contexts that are not already in the task are appended.
+func FormatTodoTxt(items []TodoTxtItem) string {
+	builder := strings.Builder{}
+
+	for _, item := range items {
+		fields := []string{}
+
+		if item.Done {
+			fields = append(fields, "x")
+		}
+		if item.Priority != "" && !item.Done {
+			fields = append(fields, "("+item.Priority+")")
+		}
+		if item.Done && item.CompletedAt != "" {
+			fields = append(fields, item.CompletedAt)
+		}
+		if item.CreatedAt != "" && (!item.Done || item.CompletedAt != "") {
+			fields = append(fields, item.CreatedAt)
+		}
+
+		task := strings.Join(strings.Fields(item.Task), " ")
+		fields = append(fields, task)
+		tags := " " + task + " "
+
+		for _, p := range item.Projects {
+			if !strings.Contains(tags, " +"+p+" ") {
+				fields = append(fields, "+"+p)
+			}
+		}
+		for _, c := range item.Contexts {
+			if !strings.Contains(tags, " @"+c+" ") {
+				fields = append(fields, "@"+c)
+			}
+		}
+
+		builder.WriteString(strings.Join(fields, " "))
+		builder.WriteRune('\n')
+	}
+
+	return builder.String()
+}
+
+// RemoveTodoTxtProject removes the +project tag from the task
+func RemoveTodoTxtProject(task, project string) string {
+	fields := strings.Fields(task)
+	for i, f := range fields {
+		if f == "+"+project {
+			fields = append(fields[:i], fields[i+1:]...)
+			break
+		}
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTodoTxt(t *testing.T) {
+	input := `(A) 2026-10-01 Call mom +family @phone due:2026-10-20
+
+x 2026-10-05 2026-10-01 Fix the bug +main @work
+x 2026-10-06 Done without creation date
+x (B) Priority  after   completion
+2026-13-01 Not a date
+`
+	items, err := ParseTodoTxt(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []TodoTxtItem{
+		{Priority: "A", CreatedAt: "2026-10-01", Task: "Call mom +family @phone due:2026-10-20", Projects: []string{"family"}, Contexts: []string{"phone"}},
+		{Done: true, CompletedAt: "2026-10-05", CreatedAt: "2026-10-01", Task: "Fix the bug +main @work", Projects: []string{"main"}, Contexts: []string{"work"}},
+		{Done: true, CompletedAt: "2026-10-06", Task: "Done without creation date"},
+		{Done: true, Priority: "B", Task: "Priority after completion"},
+		{Task: "2026-13-01 Not a date"},
+	}
+
+	if !reflect.DeepEqual(expected, items) {
+		t.Errorf("not equal.\nexpected %v\ngot      %v", expected, items)
+	}
+}
+
+func TestFormatTodoTxt(t *testing.T) {
+	items := []TodoTxtItem{
+		{Priority: "A", CreatedAt: "2026-10-01", Task: "Call mom @phone", Projects: []string{"family"}, Contexts: []string{"phone"}},
+		{Done: true, CompletedAt: "2026-10-05", CreatedAt: "2026-10-01", Task: "Fix the bug", Projects: []string{"feature/x"}},
+		{Done: true, CreatedAt: "2026-10-01", Task: "No completion date"},
+	}
+
+	expected := `(A) 2026-10-01 Call mom @phone +family
+x 2026-10-05 2026-10-01 Fix the bug +feature/x
+x No completion date
+`
+	result := FormatTodoTxt(items)
+	if result != expected {
+		t.Errorf("not equal.\nexpected %q\ngot      %q", expected, result)
+	}
+
+	parsed, err := ParseTodoTxt(strings.NewReader(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed[1].Task != "Fix the bug +feature/x" || parsed[1].Projects[0] != "feature/x" {
+		t.Errorf("round trip failed: %v", parsed[1])
+	}
+}
+
+func TestRemoveTodoTxtProject(t *testing.T) {
+	result := RemoveTodoTxtProject("Fix +main the +main bug +mainline", "main")
+	if result != "Fix the +main bug +mainline" {
+		t.Errorf("unexpected result %q", result)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// maximum number of branches shown at once in the branch picker
+const maxPickerRows = 8
+
+// BranchOptions returns the names of the branches of the repository, and the
+// options to pick them from. Branches with items that don't exist in git
+// anymore are listed after the others. The excluded branch is left out.
+func BranchOptions(db *base.TodoDb, repo, exclude string) ([]string, []PickerOption, error) {
+	items, err := db.GetBranches(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gitBranches, err := shell.ListBranches()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := slices.Clone(gitBranches)
+	pending := map[string]int{}
+	for _, b := range items {
+		pending[b.BranchName] = b.PendingCount
+		if !slices.Contains(names, b.BranchName) {
+			names = append(names, b.BranchName)
+		}
+	}
+
+	names = slices.DeleteFunc(names, func(name string) bool { return name == exclude || name == "" })
+	options := make([]PickerOption, len(names))
+
+	for i, name := range names {
+		details := []string{}
+		if pending[name] > 0 {
+			details = append(details, fmt.Sprintf("%d pending", pending[name]))
+		}
+		if !slices.Contains(gitBranches, name) {
+			details = append(details, "not in git")
+		}
+		options[i] = PickerOption{Label: name, Detail: strings.Join(details, " • ")}
+	}
+
+	return names, options, nil
+}
+
+// startBranchPicker shows the branches to move or copy the selected items to
+func (m *model) startBranchPicker() {
+	items := m.selectedItems()
+	if len(items) == 0 {
+		return
+	}
+
+	exclude := ""
+	if m.mode == ModeTodoItems {
+		exclude = m.env.Branch
+	}
+
+	names, options, err := BranchOptions(m.db, m.env.ProjDir, exclude)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	if len(names) == 0 {
+		m.errorMsg = "There are no other branches."
+		return
+	}
+
+	m.errorMsg = ""
+	m.branchNames = names
+	m.branchPicker = &pickerModel{
+		title:   fmt.Sprintf("move or copy %d item(s) to branch:", len(items)),
+		options: options,
+		height:  min(len(options), maxPickerRows) + 4,
+		width:   max(m.screenWidth-2, 0),
+		single:  true,
+		keys:    []pickerKey{{"enter", "⏎ move"}, {"c", "c copy"}},
+	}
+}
+
+// updateBranchPicker handles the keys of the branch picker, and asks to move
+// or copy the selected items once the branch is chosen
+func (m *model) updateBranchPicker(msg tea.KeyMsg) {
+	result, _ := m.branchPicker.Update(msg)
+	picker := result.(pickerModel)
+	m.branchPicker = &picker
+
+	if !picker.done {
+		return
+	}
+
+	m.branchPicker = nil
+	if !picker.confirmed {
+		return
+	}
+
+	branch := m.branchNames[picker.cursor]
+	projId := m.db.FetchProjectId(m.env.ProjDir, branch)
+
+	if picker.chosen == "c" {
+		m.confirmCopy(projId, fmt.Sprintf("%q", branch))
+	} else {
+		m.confirmMove(projId, fmt.Sprintf("%q", branch))
+	}
+}
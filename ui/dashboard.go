@@ -0,0 +1,458 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// dashBranch is a branch row of the dashboard
+type dashBranch struct {
+	repo         string
+	projId       int
+	name         string
+	pending      int
+	done         int
+	totalSec     int
+	latestUpdate string
+	timerRunning bool
+	stashes      int
+	current      bool
+}
+
+// dashboardModel is the state of the dashboard
+type dashboardModel struct {
+	db       *base.TodoDb
+	branches []dashBranch
+	missing  map[string]bool
+	cursor   int
+	offset   int
+	width    int
+	height   int
+	errorMsg string
+	infoMsg  string
+	prompt   string
+
+	// drill-down into the items of a branch
+	drill      bool
+	items      []todoItem
+	itemOffset int
+}
+
+// loadDashboard reads the branches of every repository, along with the
+// time and the latest activity from the report over the whole history
+func loadDashboard(db *base.TodoDb) ([]dashBranch, map[string]bool, error) {
+	repos, err := db.GetRepos()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report, err := db.CreateReport("", time.Now().Format(time.DateTime), "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reportProjects := map[int]*base.ReportProject{}
+	for _, repo := range report.Repos {
+		for _, p := range repo.Projects {
+			reportProjects[p.Proj.Id] = p
+		}
+	}
+
+	branches := []dashBranch{}
+	missing := map[string]bool{}
+
+	for _, repo := range repos {
+		items, err := db.GetBranches(repo)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the repository might have been moved or deleted
+		current, err := shell.CurrentBranch(repo)
+		if err != nil {
+			missing[repo] = true
+		}
+
+		stashes := map[int]int{}
+		if !missing[repo] {
+			stash, err := shell.GetRepoStashItems(repo)
+			if err != nil {
+				return nil, nil, err
+			}
+			for todoId := range stash {
+				if t := db.GetTodo(todoId); t != nil {
+					stashes[t.ProjectId]++
+				}
+			}
+		}
+
+		for _, b := range items {
+			row := dashBranch{
+				repo:    repo,
+				projId:  b.ProjectId,
+				name:    b.BranchName,
+				pending: b.PendingCount,
+				done:    b.DoneCount,
+				stashes: stashes[b.ProjectId],
+				current: b.BranchName == current,
+			}
+			if p := reportProjects[b.ProjectId]; p != nil {
+				row.totalSec = p.TotalTimeSeconds
+				row.latestUpdate = p.LatestUpdate
+				row.timerRunning = p.TimerRunning
+			}
+			branches = append(branches, row)
+		}
+	}
+
+	return branches, missing, nil
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	case tea.KeyMsg:
+		m.errorMsg, m.infoMsg = "", ""
+
+		// confirm the checkout
+		if m.prompt != "" {
+			if msg.String() == "y" || msg.String() == "Y" {
+				m.checkout()
+			}
+			m.prompt = ""
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "Q":
+			return m, tea.Quit
+		case "esc", "left", "h", "backspace":
+			if m.drill {
+				m.drill = false
+				m.items = nil
+			}
+		case "enter", "right", "l":
+			if !m.drill && len(m.branches) > 0 {
+				m.openBranch()
+			}
+		case "up", "k", "K":
+			switch {
+			case m.drill:
+				m.itemOffset = max(m.itemOffset-1, 0)
+			case m.cursor > 0:
+				m.cursor--
+			}
+		case "down", "j", "J":
+			switch {
+			case m.drill:
+				m.itemOffset = max(min(m.itemOffset+1, len(m.itemLines())-m.visibleRows()), 0)
+			case m.cursor < len(m.branches)-1:
+				m.cursor++
+			}
+		case "c", "C":
+			if len(m.branches) == 0 {
+				break
+			}
+			b := m.branches[m.cursor]
+			switch {
+			case m.missing[b.repo]:
+				m.errorMsg = "repository not found: " + b.repo
+			case b.current:
+				m.infoMsg = fmt.Sprintf("%q is already checked out", b.name)
+			default:
+				m.prompt = fmt.Sprintf("checkout %q in %s? (y/n) ", b.name, b.repo)
+			}
+		case "r", "R":
+			m.reload()
+		}
+	}
+
+	// keep the cursor within the visible rows
+	if !m.drill {
+		line, rows := m.cursorLine(), m.visibleRows()
+		if line < m.offset {
+			m.offset = line
+		} else if line >= m.offset+rows {
+			m.offset = line - rows + 1
+		}
+	}
+
+	return m, nil
+}
+
+// openBranch loads the items of the branch under the cursor
+func (m *dashboardModel) openBranch() {
+	b := m.branches[m.cursor]
+	stash := map[int]shell.StashItem{}
+	if !m.missing[b.repo] {
+		stash, _ = shell.GetRepoStashItems(b.repo)
+	}
+
+	items, _, err := loadItems(m.db, b.projId, stash)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.items = items
+	m.itemOffset = 0
+	m.drill = true
+}
+
+// checkout switches the repository to the branch under the cursor
+func (m *dashboardModel) checkout() {
+	b := m.branches[m.cursor]
+
+	if err := os.Chdir(b.repo); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	if err := shell.CheckoutBranch(b.name, "", false); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	for i := range m.branches {
+		if m.branches[i].repo == b.repo {
+			m.branches[i].current = i == m.cursor
+		}
+	}
+	m.infoMsg = fmt.Sprintf("switched to %q in %s", b.name, b.repo)
+}
+
+// reload reads the dashboard again, keeping the cursor on the same branch
+func (m *dashboardModel) reload() {
+	branches, missing, err := loadDashboard(m.db)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	projId := 0
+	if len(m.branches) > 0 {
+		projId = m.branches[m.cursor].projId
+	}
+
+	m.branches, m.missing, m.cursor = branches, missing, 0
+	for i, b := range branches {
+		if b.projId == projId {
+			m.cursor = i
+		}
+	}
+
+	if m.drill && len(m.branches) > 0 {
+		m.openBranch()
+	} else {
+		m.drill = false
+	}
+}
+
+// visibleRows returns the number of content lines that fit the screen
+func (m dashboardModel) visibleRows() int {
+	if m.height == 0 {
+		return 1000
+	}
+	return max(m.height-5, 1)
+}
+
+// cursorLine returns the line of the branch under the cursor
+func (m dashboardModel) cursorLine() int {
+	line := 0
+	for i, b := range m.branches {
+		if i == 0 || b.repo != m.branches[i-1].repo {
+			if i > 0 {
+				line++
+			}
+			line++
+		}
+		if i == m.cursor {
+			break
+		}
+		line++
+	}
+	return line
+}
+
+// activityText describes when the branch was updated last
+func activityText(latest string) string {
+	today := time.Now().Format(time.DateOnly)
+	yesterday := time.Now().Add(-24 * time.Hour).Format(time.DateOnly)
+
+	switch {
+	case latest == "":
+		return "no activity"
+	case strings.HasPrefix(latest, today):
+		return "updated today"
+	case strings.HasPrefix(latest, yesterday):
+		return "updated yesterday"
+	default:
+		return "updated on " + latest[0:10]
+	}
+}
+
+// branchLines renders the repositories and their branches
+func (m dashboardModel) branchLines() []string {
+	lines := []string{}
+
+	for i, b := range m.branches {
+		if i == 0 || b.repo != m.branches[i-1].repo {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			header := styles.bold.Render(b.repo)
+			if m.missing[b.repo] {
+				header += styles.danger.Render(" • not found")
+			}
+			lines = append(lines, header)
+		}
+
+		selected := i == m.cursor
+
+		cursor := " "
+		if selected {
+			cursor = styles.bold.Render(">")
+		}
+
+		current := " "
+		if b.current {
+			current = styles.success.Render("*")
+		}
+
+		name := b.name
+		if selected {
+			name = styles.bold.Render(name)
+		}
+
+		details := []string{
+			fmt.Sprintf("%d pending", b.pending),
+			fmt.Sprintf("%d done", b.done),
+		}
+		if b.totalSec > 0 {
+			details = append(details, base.FormatSeconds(b.totalSec))
+		}
+		details = append(details, activityText(b.latestUpdate))
+
+		line := cursor + " " + current + " " + name + styles.dimmed.Render(" • "+strings.Join(details, " • "))
+		if b.timerRunning {
+			line += " " + styles.timer.Render(" ⏱ ")
+		}
+		if b.stashes > 0 {
+			line += styles.warning.Render(fmt.Sprintf(" • %d stashed", b.stashes))
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// itemLines renders the items of the opened branch
+func (m dashboardModel) itemLines() []string {
+	if len(m.items) == 0 {
+		return []string{styles.dimmed.Render("no items")}
+	}
+
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+
+	lines := []string{}
+	for _, item := range m.items {
+		indent := strings.Repeat("  ", item.level)
+		checked := "[ ]"
+		if item.done {
+			checked = "[" + styles.checkMark + "]"
+		}
+
+		s := indent + checked + " " + item.Render(false, false, max(width-6-len(indent), 10), "    "+indent, nil)
+		lines = append(lines, strings.Split(s, "\n")...)
+	}
+
+	return lines
+}
+
+func (m dashboardModel) View() string {
+	b := strings.Builder{}
+
+	var lines []string
+	offset := m.offset
+
+	if m.drill {
+		branch := m.branches[m.cursor]
+		b.WriteString(styles.bold.Render(fmt.Sprintf("%s (%d/%d)", branch.name, branch.done, branch.pending+branch.done)))
+		b.WriteString(styles.dimmed.Render(" " + branch.repo))
+		lines = m.itemLines()
+		offset = m.itemOffset
+	} else {
+		b.WriteString(styles.bold.Render("DASHBOARD"))
+		lines = m.branchLines()
+		if len(m.branches) == 0 {
+			lines = []string{styles.dimmed.Render("no branches with items")}
+		}
+	}
+	b.WriteString("\n\n")
+
+	end := min(offset+m.visibleRows(), len(lines))
+	for _, line := range lines[min(offset, end):end] {
+		b.WriteString(line)
+		b.WriteRune('\n')
+	}
+
+	b.WriteRune('\n')
+	switch {
+	case m.prompt != "":
+		b.WriteString(styles.warning.Render(m.prompt))
+	case m.errorMsg != "":
+		b.WriteString(styles.danger.Render(m.errorMsg))
+	case m.infoMsg != "":
+		b.WriteString(styles.success.Render(m.infoMsg))
+	case m.drill:
+		b.WriteString(styles.dimmed.Render("↑/↓ scroll • c checkout • esc back • q quit"))
+	default:
+		b.WriteString(styles.dimmed.Render("⏎ items • c checkout • r reload • q quit"))
+	}
+
+	return b.String()
+}
+
+// RunDashboard shows the branches of all repositories
+func RunDashboard(db *base.TodoDb) error {
+	branches, missing, err := loadDashboard(db)
+	if err != nil {
+		return err
+	}
+
+	setupTheme()
+	m := dashboardModel{db: db, branches: branches, missing: missing}
+	_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
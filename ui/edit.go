@@ -0,0 +1,184 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// what the inline input is used for
+const (
+	editNone int = iota
+	editItem
+	editAdd
+)
+
+// newEditInput creates the input for editing the items inline
+func newEditInput(value string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = "text due:date est:duration"
+	input.PlaceholderStyle = styles.dimmed
+	input.SetValue(value)
+	input.CursorEnd()
+	return input
+}
+
+// startEdit starts editing the title of the selected item inline,
+// with the due date and the estimate as tokens
+func (m *model) startEdit() tea.Cmd {
+	coll := m.currentItems()
+	if len(coll) == 0 {
+		return nil
+	}
+
+	item := coll[m.cursor]
+	m.editing = editItem
+	m.editId = item.id
+	m.editInput = newEditInput(shell.AppendEstimate(shell.AppendDue(item.task, item.due), item.estimate))
+	m.errorMsg = ""
+	return m.editInput.Focus()
+}
+
+// startAdd starts adding items inline at the end of the current list
+func (m *model) startAdd() tea.Cmd {
+	m.editing = editAdd
+	m.editInput = newEditInput("")
+	m.errorMsg = ""
+	return m.editInput.Focus()
+}
+
+// currentItems returns the items of the list the cursor is in
+func (m model) currentItems() []todoItem {
+	switch m.mode {
+	case ModeTodoItems:
+		return m.todoItems
+	case ModeQueue:
+		return m.queueItems
+	}
+	return nil
+}
+
+// updateEdit handles the keys of the inline input. Enter saves the text,
+// and esc cancels editing. When adding, the input stays open for the next
+// item until enter is pressed on an empty input.
+func (m *model) updateEdit(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.stopEdit()
+		return nil
+	case "enter":
+		text := strings.TrimSpace(m.editInput.Value())
+
+		if m.editing == editAdd && text == "" {
+			m.stopEdit()
+			return nil
+		}
+
+		if err := m.saveEdit(text); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		if m.editing == editAdd {
+			m.editInput.Reset()
+			m.errorMsg = ""
+			return nil
+		}
+
+		m.stopEdit()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.editInput, cmd = m.editInput.Update(msg)
+	return cmd
+}
+
+// saveEdit saves the edited item, or adds the new item
+func (m *model) saveEdit(text string) error {
+	text, due, _ := shell.ExtractDue(text, time.Now())
+	text, estimate, _ := shell.ExtractEstimate(text)
+	if text == "" {
+		return errors.New("The text of the item can't be empty.")
+	}
+
+	if m.editing == editAdd {
+		projId := m.proj.Id
+		if m.mode == ModeQueue {
+			projId = m.queueProjId
+		}
+
+		err := m.db.AddTodoTree(projId, 0, []base.TodoInput{{Task: text, Due: due, Estimate: estimate}})
+		if err != nil {
+			return err
+		}
+
+		m.reloadItems(0)
+		if m.mode == ModeQueue {
+			m.cursor = len(m.queueItems) - 1
+		} else {
+			m.cursor = len(m.todoItems) - 1
+		}
+		m.fixCursor()
+		return nil
+	}
+
+	// the tokens are shown in the input, so removing them clears the values
+	err := m.db.UpdateTask(m.editId, text)
+	if err == nil {
+		err = m.db.SetDue(m.editId, due)
+	}
+	if err == nil {
+		err = m.db.SetEstimate(m.editId, estimate)
+	}
+	if err != nil {
+		return err
+	}
+
+	coll := m.currentItems()
+	for i := range coll {
+		if coll[i].id == m.editId {
+			coll[i].task = text
+			coll[i].due = due
+			coll[i].estimate = estimate
+		}
+	}
+
+	return nil
+}
+
+// stopEdit closes the inline input
+func (m *model) stopEdit() {
+	m.editing = editNone
+	m.editId = 0
+	m.editInput.Blur()
+	m.errorMsg = ""
+}
+
+// editView renders the inline input within the given width
+func (m model) editView(width int) string {
+	m.editInput.Width = max(width-1, 1)
+	return m.editInput.View()
+}
@@ -0,0 +1,363 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fuzzyMatch matches the pattern against the text ignoring the case, first
+// as a substring and then as a subsequence of the characters of the pattern,
+// ignoring the spaces. It returns the positions of the matched runes.
+func fuzzyMatch(text, pattern string) ([]int, bool) {
+	t := []rune(strings.ToLower(text))
+	p := []rune(strings.ToLower(strings.TrimSpace(pattern)))
+
+	if len(p) == 0 || len(t) != len([]rune(text)) {
+		return nil, false
+	}
+
+	for i := 0; i+len(p) <= len(t); i++ {
+		if slices.Equal(t[i:i+len(p)], p) {
+			positions := make([]int, len(p))
+			for j := range p {
+				positions[j] = i + j
+			}
+			return positions, true
+		}
+	}
+
+	positions := []int{}
+	i := 0
+	for _, r := range p {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for i < len(t) && t[i] != r {
+			i++
+		}
+		if i == len(t) {
+			return nil, false
+		}
+		positions = append(positions, i)
+		i++
+	}
+
+	return positions, true
+}
+
+// highlightMatches renders the wrapped text with the matched runes of the
+// original text highlighted. Wrapping only changes the white space, so the
+// other runes of both texts are in the same order.
+func highlightMatches(wrapped, original string, matches []int, bold bool) string {
+	if len(matches) == 0 {
+		if bold {
+			return styles.bold.Render(wrapped)
+		}
+		return wrapped
+	}
+
+	matched := map[int]bool{}
+	for _, i := range matches {
+		matched[i] = true
+	}
+
+	// flags of the runes of the original text that are not white space
+	flags := []bool{}
+	for i, r := range []rune(original) {
+		if !unicode.IsSpace(r) {
+			flags = append(flags, matched[i])
+		}
+	}
+
+	b := strings.Builder{}
+	segment := []rune{}
+	segmentMatched := false
+
+	flush := func() {
+		if len(segment) == 0 {
+			return
+		}
+		style := lipgloss.NewStyle()
+		if segmentMatched {
+			style = styles.match
+		}
+		if bold || segmentMatched {
+			b.WriteString(style.Bold(bold).Render(string(segment)))
+		} else {
+			b.WriteString(string(segment))
+		}
+		segment = segment[:0]
+	}
+
+	k := 0
+	for _, r := range wrapped {
+		if unicode.IsSpace(r) {
+			flush()
+			b.WriteRune(r)
+			continue
+		}
+
+		isMatch := k < len(flags) && flags[k]
+		k++
+
+		if isMatch != segmentMatched {
+			flush()
+			segmentMatched = isMatch
+		}
+		segment = append(segment, r)
+	}
+	flush()
+
+	return b.String()
+}
+
+// filterActive tells if the lists are filtered
+func (m model) filterActive() bool {
+	return strings.TrimSpace(m.filter) != ""
+}
+
+// updateMatches matches the items of both lists against the filter, and
+// marks the to-do items to be shown, which are the matched items and their
+// parents. The cursor is moved off the items that are not shown.
+func (m *model) updateMatches() {
+	m.matches = map[int][]int{}
+	m.shown = map[int]bool{}
+
+	if !m.filterActive() {
+		return
+	}
+
+	for i, t := range m.todoItems {
+		if positions, ok := fuzzyMatch(t.task, m.filter); ok {
+			m.matches[t.id] = positions
+			m.shown[t.id] = true
+			for p := m.parentIndex(i); p >= 0 && !m.shown[m.todoItems[p].id]; p = m.parentIndex(p) {
+				m.shown[m.todoItems[p].id] = true
+			}
+		}
+	}
+
+	for _, t := range m.queueItems {
+		if positions, ok := fuzzyMatch(t.task, m.filter); ok {
+			m.matches[t.id] = positions
+			m.shown[t.id] = true
+		}
+	}
+}
+
+// isQueueHidden tells if the queue item at the index is filtered out
+func (m model) isQueueHidden(i int) bool {
+	return m.filterActive() && !m.shown[m.queueItems[i].id]
+}
+
+// prevVisibleQueue returns the index of the first visible queue item
+// before the index, or -1 if there is none
+func (m model) prevVisibleQueue(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if !m.isQueueHidden(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// nextVisibleQueue returns the index of the first visible queue item
+// after the index, or -1 if there is none
+func (m model) nextVisibleQueue(i int) int {
+	for j := i + 1; j < len(m.queueItems); j++ {
+		if !m.isQueueHidden(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// cursorHidden tells if the item under the cursor is filtered out,
+// which happens only when none of the items match the filter
+func (m model) cursorHidden() bool {
+	switch {
+	case m.mode == ModeTodoItems && m.cursor < len(m.todoItems):
+		return m.isHidden(m.cursor)
+	case m.mode == ModeQueue && m.cursor < len(m.queueItems):
+		return m.isQueueHidden(m.cursor)
+	}
+	return false
+}
+
+// jumpToMatch moves the cursor to the next or the previous item matching
+// the filter, going over both lists and wrapping around at the ends.
+// If from is true, the item under the cursor is considered as well.
+func (m *model) jumpToMatch(forward, from bool) bool {
+	type pos struct{ mode, index int }
+
+	all := make([]pos, 0, len(m.todoItems)+len(m.queueItems))
+	current := -1
+	for i, t := range m.todoItems {
+		if m.mode == ModeTodoItems && i == m.cursor {
+			current = len(all)
+		}
+		if _, ok := m.matches[t.id]; ok || (m.mode == ModeTodoItems && i == m.cursor) {
+			all = append(all, pos{ModeTodoItems, i})
+		}
+	}
+	for i, t := range m.queueItems {
+		if m.mode == ModeQueue && i == m.cursor {
+			current = len(all)
+		}
+		if _, ok := m.matches[t.id]; ok || (m.mode == ModeQueue && i == m.cursor) {
+			all = append(all, pos{ModeQueue, i})
+		}
+	}
+
+	if len(all) == 0 {
+		return false
+	}
+
+	step := 1
+	if !forward {
+		step = -1
+	}
+
+	start := current
+	if current < 0 {
+		start, from = 0, true
+	}
+
+	for n := range len(all) {
+		k := start + n*step
+		if !from {
+			k += step
+		}
+		p := all[((k%len(all))+len(all))%len(all)]
+
+		var id int
+		if p.mode == ModeTodoItems {
+			id = m.todoItems[p.index].id
+		} else {
+			id = m.queueItems[p.index].id
+		}
+
+		if _, ok := m.matches[id]; ok {
+			if p.mode != m.mode {
+				m.stateMode(p.mode)
+			}
+			m.cursor = p.index
+			return true
+		}
+	}
+
+	return false
+}
+
+// startFilter focuses the filter input for typing
+func (m *model) startFilter() tea.Cmd {
+	m.filterInput = textinput.New()
+	m.filterInput.Prompt = "/"
+	m.filterInput.Placeholder = "filter"
+	m.filterInput.PlaceholderStyle = styles.dimmed
+	m.filterInput.SetValue(m.filter)
+	m.filtering = true
+	m.errorMsg = ""
+	return m.filterInput.Focus()
+}
+
+// updateFilter handles the keys while typing the filter. Enter keeps the
+// filter and esc clears it, while the arrows move between the matches.
+func (m *model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.filtering = false
+		m.filterInput.Blur()
+		return nil
+	case "esc", "ctrl+c":
+		m.clearFilter()
+		return nil
+	case "up", "shift+tab":
+		m.jumpToMatch(false, false)
+		return nil
+	case "down", "tab":
+		m.jumpToMatch(true, false)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+
+	if m.filterInput.Value() != m.filter {
+		m.filter = m.filterInput.Value()
+		m.updateMatches()
+		m.jumpToMatch(true, true)
+	}
+
+	return cmd
+}
+
+// clearFilter stops filtering and shows all of the items again
+func (m *model) clearFilter() {
+	m.filtering = false
+	m.filter = ""
+	m.filterInput.Blur()
+	m.updateMatches()
+}
+
+// filterView renders the filter in the footer
+func (m model) filterView() string {
+	if m.filtering {
+		return m.filterInput.View()
+	}
+
+	s := "/" + m.filter
+	clearHint := hintsView(hint("to clear", m.keys.clear))
+	switch count := len(m.matches); count {
+	case 0:
+		return styles.danger.Render(s+" • no matches") + styles.dimmed.Render(clearHint)
+	case 1:
+		s += " • 1 match"
+	default:
+		s += fmt.Sprintf(" • %d matches", count)
+	}
+	hints := hintsView(hint("next/previous", m.keys.nextMatch, m.keys.prevMatch))
+	return styles.warning.Render(s) + styles.dimmed.Render(hints+clearHint)
+}
+
+// scrollToCursor scrolls the viewport so that the cursor is visible
+func (m *model) scrollToCursor() {
+	content, rows := m.layout()
+	m.viewport.SetContent(content)
+
+	i := slices.IndexFunc(rows, func(r itemRow) bool {
+		return r.mode == m.mode && r.index == m.cursor
+	})
+	if i < 0 {
+		return
+	}
+
+	switch row := rows[i]; {
+	case row.start < m.viewport.YOffset:
+		m.viewport.SetYOffset(row.start)
+	case row.end > m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(min(row.start, row.end-m.viewport.Height))
+	}
+}
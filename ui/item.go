@@ -18,6 +18,9 @@ package ui
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/drazengolic/gitodo/base"
 	"github.com/drazengolic/gitodo/shell"
 	"github.com/drazengolic/gitodo/wordwrap"
 )
@@ -25,30 +28,71 @@ import (
 // item rendered in both sections
 type todoItem struct {
 	id              int
-	task            string
+	parentId        int
+	level           int
+	folded          int
+	task, notes     string
+	due             string
+	estimate        int
+	source          string
 	done, committed bool
 	stash           shell.StashItem
 }
 
-// Render renders a single to-do item
-func (i todoItem) Render(bold, showId bool, width int, glue string) string {
-	var s string
-	switch {
-	case bold && showId:
-		s = boldText.Render(wordwrap.WrapText(fmt.Sprintf("[#%d] %s", i.id, i.task), width, glue))
-	case bold:
-		s = boldText.Render(wordwrap.WrapText(i.task, width, glue))
-	case showId:
-		s = wordwrap.WrapText(fmt.Sprintf("[#%d] %s", i.id, i.task), width, glue)
-	default:
-		s = wordwrap.WrapText(i.task, width, glue)
+// text returns the title followed by the notes, as edited in the editor
+func (i todoItem) text() string {
+	if i.notes == "" {
+		return i.task
+	}
+	return i.task + "\n\n" + i.notes
+}
+
+// Render renders a single to-do item, with the runes of the title
+// at the matched positions highlighted
+func (i todoItem) Render(bold, showId bool, width int, glue string, matches []int) string {
+	text := i.task
+	if showId {
+		prefix := fmt.Sprintf("[#%d] ", i.id)
+		text = prefix + i.task
+
+		shifted := make([]int, len(matches))
+		for j, pos := range matches {
+			shifted[j] = pos + len([]rune(prefix))
+		}
+		matches = shifted
+	}
+
+	s := highlightMatches(wordwrap.WrapText(text, width, glue), text, matches, bold)
+
+	if i.notes != "" {
+		s += styles.dimmed.Render(" ✎")
+	}
+
+	if i.due != "" && !i.done {
+		today := time.Now().Format(time.DateOnly)
+		switch {
+		case i.due < today:
+			s += styles.danger.Render(" • overdue since " + i.due)
+		case i.due == today:
+			s += styles.warning.Render(" • due today")
+		default:
+			s += styles.dimmed.Render(" • due " + i.due)
+		}
+	}
+
+	if i.estimate > 0 {
+		s += styles.dimmed.Render(" • est " + base.FormatEstimate(i.estimate))
+	}
+
+	if i.folded > 0 {
+		s += styles.dimmed.Render(fmt.Sprintf(" (+%d)", i.folded))
 	}
 
 	if i.committed {
-		s += fmt.Sprintf("\n%s• %s", glue, committedBox)
+		s += fmt.Sprintf("\n%s• %s", glue, styles.committed)
 	}
 	if i.stash.Date != "" {
-		s += fmt.Sprintf("\n%s• %s", glue, orangeText.Render("stashed: "+i.stash.Date))
+		s += fmt.Sprintf("\n%s• %s", glue, styles.warning.Render("stashed: "+i.stash.Date))
 	}
 	return s
 }
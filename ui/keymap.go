@@ -0,0 +1,286 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// keyMap holds the key bindings of the to-do list screen
+type keyMap struct {
+	up, down           key.Binding
+	toggleDone         key.Binding
+	mark, markRange    key.Binding
+	pushTop            key.Binding
+	pushUp, pushDown   key.Binding
+	indent, outdent    key.Binding
+	collapse, expand   key.Binding
+	delete             key.Binding
+	confirm, cancel    key.Binding
+	edit, editExternal key.Binding
+	notes              key.Binding
+	add, addExternal   key.Binding
+	move, branch       key.Binding
+	stash, popStash    key.Binding
+	stashes            key.Binding
+	filter             key.Binding
+	nextMatch          key.Binding
+	prevMatch          key.Binding
+	clear              key.Binding
+	timer, sessions    key.Binding
+	showId             key.Binding
+	help               key.Binding
+	quit               key.Binding
+}
+
+// defaultKeyMap returns the built-in key bindings
+func defaultKeyMap() keyMap {
+	return keyMap{
+		up:           key.NewBinding(key.WithKeys("up", "k", "K"), key.WithHelp("K", "Up")),
+		down:         key.NewBinding(key.WithKeys("down", "j", "J"), key.WithHelp("J", "Down")),
+		toggleDone:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("⏎", "Toggle done")),
+		mark:         key.NewBinding(key.WithKeys(" "), key.WithHelp("⎵", "Mark")),
+		markRange:    key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "Mark range")),
+		pushTop:      key.NewBinding(key.WithKeys("t", "T"), key.WithHelp("T", "Push to top")),
+		pushUp:       key.NewBinding(key.WithKeys("ctrl+up", "ctrl+k"), key.WithHelp("^K", "Push up")),
+		pushDown:     key.NewBinding(key.WithKeys("ctrl+down", "ctrl+j"), key.WithHelp("^J", "Push down")),
+		indent:       key.NewBinding(key.WithKeys("tab"), key.WithHelp("⇥", "Indent")),
+		outdent:      key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("⇧⇥", "Outdent")),
+		collapse:     key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "Collapse")),
+		expand:       key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "Expand")),
+		delete:       key.NewBinding(key.WithKeys("d", "D"), key.WithHelp("D", "Delete")),
+		confirm:      key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("Y", "Confirm")),
+		cancel:       key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("N", "Cancel")),
+		edit:         key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "Edit")),
+		editExternal: key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "Edit in editor")),
+		notes:        key.NewBinding(key.WithKeys("i", "I"), key.WithHelp("I", "Notes")),
+		add:          key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "Add item")),
+		addExternal:  key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "Add in editor")),
+		move:         key.NewBinding(key.WithKeys("m", "M"), key.WithHelp("M", "Move to queue")),
+		branch:       key.NewBinding(key.WithKeys("b", "B"), key.WithHelp("B", "Move/copy to branch")),
+		stash:        key.NewBinding(key.WithKeys("s", "S"), key.WithHelp("S", "Stash")),
+		popStash:     key.NewBinding(key.WithKeys("p", "P"), key.WithHelp("P", "Pop stash")),
+		stashes:      key.NewBinding(key.WithKeys("z", "Z"), key.WithHelp("Z", "Stashes")),
+		filter:       key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "Filter")),
+		nextMatch:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "Next match")),
+		prevMatch:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "Previous match")),
+		clear:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "Clear")),
+		timer:        key.NewBinding(key.WithKeys("x", "X"), key.WithHelp("X", "Start/stop timer")),
+		sessions:     key.NewBinding(key.WithKeys("w", "W"), key.WithHelp("W", "Sessions")),
+		showId:       key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "Show ids")),
+		help:         key.NewBinding(key.WithKeys("?", "h", "H"), key.WithHelp("?", "Help")),
+		quit:         key.NewBinding(key.WithKeys("ctrl+c", "q", "Q"), key.WithHelp("Q", "Quit")),
+	}
+}
+
+// named returns the bindings by their names in git config,
+// lowercased as git does with the variable names
+func (k *keyMap) named() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":           &k.up,
+		"down":         &k.down,
+		"done":         &k.toggleDone,
+		"mark":         &k.mark,
+		"markrange":    &k.markRange,
+		"pushtop":      &k.pushTop,
+		"pushup":       &k.pushUp,
+		"pushdown":     &k.pushDown,
+		"indent":       &k.indent,
+		"outdent":      &k.outdent,
+		"collapse":     &k.collapse,
+		"expand":       &k.expand,
+		"delete":       &k.delete,
+		"confirm":      &k.confirm,
+		"cancel":       &k.cancel,
+		"edit":         &k.edit,
+		"editexternal": &k.editExternal,
+		"notes":        &k.notes,
+		"add":          &k.add,
+		"addexternal":  &k.addExternal,
+		"move":         &k.move,
+		"branch":       &k.branch,
+		"stash":        &k.stash,
+		"popstash":     &k.popStash,
+		"stashes":      &k.stashes,
+		"filter":       &k.filter,
+		"nextmatch":    &k.nextMatch,
+		"prevmatch":    &k.prevMatch,
+		"clear":        &k.clear,
+		"timer":        &k.timer,
+		"sessions":     &k.sessions,
+		"showid":       &k.showId,
+		"help":         &k.help,
+		"quit":         &k.quit,
+	}
+}
+
+// loadKeyMap returns the default key bindings overridden with the ones
+// from git config, i.e. "git config gitodo.keys.pushUp 'ctrl+w ctrl+up'".
+// Keys are separated with spaces or commas, and "space" stands for
+// the space bar. The first key is shown in the help.
+func loadKeyMap() keyMap {
+	k := defaultKeyMap()
+	named := k.named()
+
+	for name, value := range shell.GitConfigSection("gitodo.keys") {
+		b, ok := named[name]
+		if !ok {
+			continue
+		}
+
+		keys := strings.Fields(strings.ReplaceAll(value, ",", " "))
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+			continue
+		}
+
+		for i, s := range keys {
+			if s == "space" {
+				keys[i] = " "
+			}
+		}
+
+		b.SetKeys(keys...)
+		b.SetHelp(helpKey(keys[0]), b.Help().Desc)
+	}
+
+	return k
+}
+
+// helpKey returns a short label of the key for the help
+func helpKey(k string) string {
+	switch k {
+	case " ":
+		return "⎵"
+	case "enter":
+		return "⏎"
+	case "tab":
+		return "⇥"
+	case "shift+tab":
+		return "⇧⇥"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	}
+
+	if c, ok := strings.CutPrefix(k, "ctrl+"); ok && len(c) == 1 {
+		return "^" + strings.ToUpper(c)
+	}
+
+	return k
+}
+
+// helpEntry is a binding shown in the help, with the description
+// that might differ from the one of the binding
+type helpEntry struct {
+	binding key.Binding
+	desc    string
+}
+
+// helpEntries returns the bindings to show in the help for the mode
+func (k keyMap) helpEntries(mode int) []helpEntry {
+	var bindings []helpEntry
+
+	if mode == ModeQueue {
+		bindings = []helpEntry{
+			{k.up, ""}, {k.down, ""}, {k.move, "Make todo"}, {k.branch, ""},
+			{k.mark, ""}, {k.markRange, ""}, {k.edit, ""}, {k.editExternal, ""},
+			{k.notes, ""}, {k.delete, ""}, {k.add, ""}, {k.addExternal, ""},
+			{k.stashes, ""}, {k.filter, ""}, {k.timer, ""}, {k.sessions, ""},
+			{k.quit, ""},
+		}
+	} else {
+		bindings = []helpEntry{
+			{k.up, ""}, {k.down, ""}, {k.toggleDone, ""}, {k.mark, ""},
+			{k.markRange, ""}, {k.pushUp, ""}, {k.pushDown, ""}, {k.pushTop, ""},
+			{k.indent, ""}, {k.outdent, ""}, {k.collapse, ""}, {k.expand, ""},
+			{k.edit, ""}, {k.editExternal, ""}, {k.notes, ""}, {k.delete, ""},
+			{k.move, ""}, {k.branch, ""}, {k.stash, ""}, {k.popStash, ""},
+			{k.stashes, ""}, {k.add, ""}, {k.addExternal, ""}, {k.filter, ""}, {k.timer, ""},
+			{k.sessions, ""}, {k.quit, ""},
+		}
+	}
+
+	entries := make([]helpEntry, 0, len(bindings))
+	for _, e := range bindings {
+		if !e.binding.Enabled() {
+			continue
+		}
+		if e.desc == "" {
+			e.desc = e.binding.Help().Desc
+		}
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+// helpRows renders the help entries of the mode in rows of the help table
+func (k keyMap) helpRows(mode int) [][]string {
+	entries := k.helpEntries(mode)
+	keys := make([]string, len(entries))
+
+	for i, e := range entries {
+		label := e.binding.Help().Key
+		pad := strings.Repeat(" ", max(2-lipgloss.Width(label), 0))
+		keys[i] = pad + label + " " + styles.dimmed.Render(e.desc)
+	}
+
+	return slices.Collect(slices.Chunk(keys, 5))
+}
+
+// footerHint is a hint of the footer with the bindings sharing the description
+type footerHint struct {
+	bindings []key.Binding
+	desc     string
+}
+
+// hint returns a footer hint of the bindings
+func hint(desc string, bindings ...key.Binding) footerHint {
+	return footerHint{bindings: bindings, desc: desc}
+}
+
+// hintsView renders the hints of the enabled bindings, each preceded with
+// a separator, where the keys of the bindings of a hint are joined with "/"
+func hintsView(hints ...footerHint) string {
+	var sb strings.Builder
+
+	for _, h := range hints {
+		keys := make([]string, 0, len(h.bindings))
+		for _, b := range h.bindings {
+			if b.Enabled() {
+				keys = append(keys, b.Help().Key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		sb.WriteString(" • " + strings.Join(keys, "/") + " " + h.desc)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gen2brain/beeep"
+)
+
+// maximum time between the clicks of a double-click
+const doubleClickTime = 400 * time.Millisecond
+
+// mouseState tracks the last click and the dragged item
+type mouseState struct {
+	lastRow   itemRow
+	lastClick time.Time
+	dragging  bool
+	dragFrom  int
+	dragOver  int
+}
+
+// rowAt returns the row of the item shown at the line of the screen
+func (m model) rowAt(y int) (itemRow, bool) {
+	top := strings.Count(m.headerView(), "\n") + 1
+	if y < top || y >= top+m.viewport.Height {
+		return itemRow{}, false
+	}
+
+	line := y - top + m.viewport.YOffset
+	_, rows := m.layout()
+	for _, row := range rows {
+		if line >= row.start && line < row.end {
+			return row, true
+		}
+	}
+
+	return itemRow{}, false
+}
+
+// updateMouse moves the cursor to the clicked item, toggles the to-do item
+// on double-click, and reorders the to-do items by dragging them over their
+// siblings. The wheel is handled by the viewport.
+func (m *model) updateMouse(msg tea.MouseMsg) {
+	if msg.Button != tea.MouseButtonLeft && msg.Button != tea.MouseButtonNone {
+		return
+	}
+
+	// clicks are ignored while typing or confirming
+	if m.mode == ModeInput || m.editing != editNone || m.filtering || m.branchPicker != nil || m.stashBrowser != nil {
+		m.mouse.dragging = false
+		return
+	}
+
+	row, ok := m.rowAt(msg.Y)
+
+	switch msg.Action {
+	case tea.MouseActionPress:
+		if !ok || (m.rangeActive && row.mode != m.mode) {
+			return
+		}
+
+		double := row == m.mouse.lastRow && time.Since(m.mouse.lastClick) < doubleClickTime
+
+		if row.mode != m.mode {
+			m.stateMode(row.mode)
+		}
+		m.cursor = row.index
+		m.errorMsg = ""
+
+		if double && row.mode == ModeTodoItems {
+			m.mouse.lastClick = time.Time{}
+			m.toggleDone()
+			return
+		}
+
+		m.mouse.lastRow, m.mouse.lastClick = row, time.Now()
+		if row.mode == ModeTodoItems && !m.filterActive() {
+			m.mouse.dragging = true
+			m.mouse.dragFrom, m.mouse.dragOver = row.index, row.index
+		}
+
+	case tea.MouseActionMotion:
+		if m.mouse.dragging && ok && row.mode == ModeTodoItems {
+			m.mouse.dragOver = row.index
+		}
+
+	case tea.MouseActionRelease:
+		if !m.mouse.dragging {
+			return
+		}
+		m.mouse.dragging = false
+		if ok && row.mode == ModeTodoItems && row.index != m.mouse.dragFrom {
+			m.dropItem(m.mouse.dragFrom, row.index)
+		}
+	}
+}
+
+// dropItem moves the dragged to-do item to the place of the sibling it was
+// dropped on, or of the sibling that contains the item it was dropped on
+func (m *model) dropItem(from, to int) {
+	item := m.todoItems[from]
+
+	target := to
+	for target >= 0 && m.todoItems[target].level > item.level {
+		target = m.parentIndex(target)
+	}
+
+	switch {
+	case target == from:
+		// dropped within its own sub-items
+		return
+	case target < 0 || m.parentIndex(target) != m.parentIndex(from):
+		beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
+		m.errorMsg = "Items can be reordered only among their siblings."
+		return
+	}
+
+	to = target + 1
+	if target > from {
+		to = m.subtreeEnd(target) + 1
+	}
+
+	if err := m.db.ChangePosition(item.id, from+1, to); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.reloadItems(item.id)
+}
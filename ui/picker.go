@@ -0,0 +1,232 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/wordwrap"
+)
+
+// PickerOption is an option of the picker, where the detail
+// is shown dimmed next to the label
+type PickerOption struct {
+	Label, Detail string
+	Selected      bool
+}
+
+// pickerKey is a key that confirms the choice in the single choice mode
+type pickerKey struct {
+	key, help string
+}
+
+// pickerModel is the state of the picker. In the single choice mode, the
+// option under the cursor is chosen with one of the keys.
+type pickerModel struct {
+	title     string
+	options   []PickerOption
+	cursor    int
+	offset    int
+	height    int
+	width     int
+	confirmed bool
+	single    bool
+	keys      []pickerKey
+	chosen    string
+	done      bool
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+
+	case tea.KeyMsg:
+		if m.single && len(m.options) > 0 {
+			for _, k := range m.keys {
+				if msg.String() == k.key {
+					m.confirmed, m.chosen, m.done = true, k.key, true
+					return m, tea.Quit
+				}
+			}
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "Q", "esc":
+			m.done = true
+			return m, tea.Quit
+		case "enter":
+			if m.single {
+				break
+			}
+			m.confirmed, m.done = true, true
+			return m, tea.Quit
+		case "up", "k", "K":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j", "J":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case " ", "x", "X":
+			if !m.single {
+				m.options[m.cursor].Selected = !m.options[m.cursor].Selected
+			}
+		case "a", "A":
+			if m.single {
+				break
+			}
+			all := true
+			for _, o := range m.options {
+				all = all && o.Selected
+			}
+			for i := range m.options {
+				m.options[i].Selected = !all
+			}
+		}
+	}
+
+	// keep the cursor within the visible rows
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+
+	return m, nil
+}
+
+// visibleRows returns the number of options that fit the screen
+func (m pickerModel) visibleRows() int {
+	if m.height == 0 {
+		return len(m.options)
+	}
+	return max(m.height-4, 1)
+}
+
+func (m pickerModel) View() string {
+	b := strings.Builder{}
+	b.WriteString(styles.bold.Render(m.title))
+	b.WriteString("\n\n")
+
+	end := min(m.offset+m.visibleRows(), len(m.options))
+
+	for i := m.offset; i < end; i++ {
+		o := m.options[i]
+		selected := i == m.cursor
+
+		cursor := " "
+		if selected {
+			cursor = styles.bold.Render(">")
+		}
+
+		checked := "[ ] "
+		switch {
+		case m.single:
+			checked = ""
+		case o.Selected:
+			checked = "[" + styles.checkMark + "] "
+		}
+
+		label := o.Label
+		if m.width > 0 {
+			label = wordwrap.WrapText(label, max(m.width-6-len(o.Detail), 10), "")
+			label, _, _ = strings.Cut(label, "\n")
+		}
+		if selected {
+			label = styles.bold.Render(label)
+		}
+
+		b.WriteString(cursor + " " + checked + label)
+		if o.Detail != "" {
+			b.WriteString(styles.dimmed.Render(" " + o.Detail))
+		}
+		b.WriteRune('\n')
+	}
+
+	b.WriteRune('\n')
+	b.WriteString(styles.dimmed.Render(m.helpText()))
+	return b.String()
+}
+
+// helpText describes the keys of the picker
+func (m pickerModel) helpText() string {
+	if !m.single {
+		return "⎵ select • a all • ⏎ confirm • q cancel"
+	}
+
+	help := []string{}
+	for _, k := range m.keys {
+		help = append(help, k.help)
+	}
+	return strings.Join(append(help, "q cancel"), " • ")
+}
+
+// RunPicker shows the options and lets the user select any of them.
+// It returns the indexes of the selected options, or nil if cancelled.
+func RunPicker(title string, options []PickerOption) ([]int, error) {
+	setupTheme()
+	m := pickerModel{title: title, options: options}
+	result, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	m = result.(pickerModel)
+	if !m.confirmed {
+		return nil, nil
+	}
+
+	selected := []int{}
+	for i, o := range m.options {
+		if o.Selected {
+			selected = append(selected, i)
+		}
+	}
+
+	return selected, nil
+}
+
+// RunChooser shows the options and lets the user choose one of them.
+// It returns the index of the chosen option, or -1 if cancelled.
+func RunChooser(title string, options []PickerOption) (int, error) {
+	setupTheme()
+	m := pickerModel{
+		title:   title,
+		options: options,
+		single:  true,
+		keys:    []pickerKey{{"enter", "⏎ choose"}},
+	}
+	result, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return -1, err
+	}
+
+	m = result.(pickerModel)
+	if !m.confirmed {
+		return -1, nil
+	}
+
+	return m.cursor, nil
+}
@@ -0,0 +1,378 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// bulk operations on the selected items that need confirmation via prompt
+
+type opDelItems struct {
+	ids  []int
+	mode int
+}
+
+type opMoveItems struct {
+	ids    []int
+	projId int
+	mode   int
+}
+
+type opCopyItems struct {
+	ids    []int
+	projId int
+	mode   int
+}
+
+// toggleMark marks or unmarks the item under the cursor. Items can be marked
+// only in one of the lists at a time, so marking an item of the other list
+// starts a new selection.
+func (m *model) toggleMark() {
+	coll := m.currentItems()
+	if len(coll) == 0 {
+		return
+	}
+
+	if m.markMode != m.mode {
+		m.clearSelection()
+		m.markMode = m.mode
+	}
+
+	id := coll[m.cursor].id
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+}
+
+// toggleRange starts selecting the items from the cursor to where it's
+// moved, or marks the selected range when it's toggled again
+func (m *model) toggleRange() {
+	if m.rangeActive {
+		for _, id := range m.selectionIds() {
+			m.marked[id] = true
+		}
+		m.rangeActive = false
+		return
+	}
+
+	if len(m.currentItems()) == 0 {
+		return
+	}
+
+	if m.markMode != m.mode {
+		m.clearSelection()
+		m.markMode = m.mode
+	}
+
+	m.rangeActive = true
+	m.rangeAnchor = m.cursor
+}
+
+// clearSelection unmarks all of the items
+func (m *model) clearSelection() {
+	m.marked = map[int]bool{}
+	m.rangeActive = false
+}
+
+// hasSelection tells if there are selected items in the current list
+func (m model) hasSelection() bool {
+	return m.markMode == m.mode && (m.rangeActive || len(m.marked) > 0)
+}
+
+// isMarked tells if the item at the index of the list is selected,
+// either marked or within the range being selected
+func (m model) isMarked(mode, i int) bool {
+	if m.markMode != mode {
+		return false
+	}
+
+	coll := m.todoItems
+	if mode == ModeQueue {
+		coll = m.queueItems
+	}
+
+	if m.marked[coll[i].id] {
+		return true
+	}
+
+	return m.rangeActive && m.mode == mode &&
+		i >= min(m.rangeAnchor, m.cursor) && i <= max(m.rangeAnchor, m.cursor)
+}
+
+// selectionIds returns the ids of the selected items that are shown,
+// in the order of the list
+func (m model) selectionIds() []int {
+	coll := m.todoItems
+	hidden := m.isHidden
+	if m.markMode == ModeQueue {
+		coll = m.queueItems
+		hidden = m.isQueueHidden
+	}
+
+	ids := []int{}
+	for i, t := range coll {
+		if m.isMarked(m.markMode, i) && !hidden(i) {
+			ids = append(ids, t.id)
+		}
+	}
+	return ids
+}
+
+// selectedItems returns the selected items of the current list, or the item
+// under the cursor if nothing is selected
+func (m model) selectedItems() []todoItem {
+	coll := m.currentItems()
+	if len(coll) == 0 {
+		return nil
+	}
+	if !m.hasSelection() {
+		return []todoItem{coll[m.cursor]}
+	}
+
+	ids := m.selectionIds()
+	items := []todoItem{}
+	for _, t := range coll {
+		if slices.Contains(ids, t.id) {
+			items = append(items, t)
+		}
+	}
+	return items
+}
+
+// itemIds returns the ids of the items
+func itemIds(items []todoItem) []int {
+	ids := make([]int, len(items))
+	for i, t := range items {
+		ids[i] = t.id
+	}
+	return ids
+}
+
+// toggleSelectionDone completes the selected to-do items, or reopens
+// them if all of them are completed
+func (m *model) toggleSelectionDone() {
+	items := m.selectedItems()
+	done := slices.ContainsFunc(items, func(t todoItem) bool { return !t.done })
+
+	if err := m.db.TodosDone(itemIds(items), done); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.clearSelection()
+	m.reloadItems(m.todoItems[m.cursor].id)
+}
+
+// toggleDone completes or reopens the selected to-do items,
+// or the one under the cursor if nothing is selected
+func (m *model) toggleDone() {
+	if m.hasSelection() {
+		m.toggleSelectionDone()
+		return
+	}
+	if len(m.todoItems) == 0 {
+		return
+	}
+
+	item := m.todoItems[m.cursor]
+	err := m.db.TodoDone(item.id, !item.done)
+	if err != nil {
+		m.errorMsg = err.Error()
+	} else if item.level > 0 {
+		// parents might have been completed or reopened as well
+		m.reloadItems(item.id)
+	} else {
+		m.todoItems[m.cursor].done = !item.done
+
+		if !item.done {
+			m.doneCount++
+		} else {
+			m.doneCount--
+		}
+	}
+}
+
+// confirmDelete asks to delete the selected items
+func (m *model) confirmDelete() {
+	items := m.selectedItems()
+	if slices.ContainsFunc(items, func(t todoItem) bool { return t.done }) {
+		m.errorMsg = "Completed items can't be deleted."
+		return
+	}
+
+	mode := m.mode
+	m.stateMode(ModeInput)
+	m.prompt = fmt.Sprintf("delete %d item(s): are you sure? (y/n) ", len(items))
+	m.pendingOp = opDelItems{ids: itemIds(items), mode: mode}
+}
+
+// confirmMove asks to move the selected items to the project
+func (m *model) confirmMove(projId int, where string) {
+	items := m.selectedItems()
+	if slices.ContainsFunc(items, func(t todoItem) bool { return t.done }) {
+		m.errorMsg = "Completed items can't be moved."
+		return
+	}
+
+	mode := m.mode
+	m.stateMode(ModeInput)
+	m.prompt = fmt.Sprintf("move %d item(s) to %s? (y/n) ", len(items), where)
+	m.pendingOp = opMoveItems{ids: itemIds(items), projId: projId, mode: mode}
+}
+
+// confirmCopy asks to copy the selected items to the project
+func (m *model) confirmCopy(projId int, where string) {
+	items := m.selectedItems()
+	mode := m.mode
+	m.stateMode(ModeInput)
+	m.prompt = fmt.Sprintf("copy %d item(s) to %s? (y/n) ", len(items), where)
+	m.pendingOp = opCopyItems{ids: itemIds(items), projId: projId, mode: mode}
+}
+
+// applyBulkOp applies the confirmed bulk operation and
+// returns to the list the items were selected in
+func (m *model) applyBulkOp(op any) {
+	var err error
+	mode := ModeTodoItems
+
+	switch op := op.(type) {
+	case opDelItems:
+		mode = op.mode
+		err = m.db.DeleteTodos(op.ids)
+	case opMoveItems:
+		mode = op.mode
+		err = m.db.MoveTodos(op.ids, op.projId)
+	case opCopyItems:
+		mode = op.mode
+		_, err = m.db.CopyTodos(op.ids, op.projId)
+	}
+
+	m.stateMode(mode)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.clearSelection()
+	m.reloadItems(0)
+
+	switch {
+	case mode == ModeTodoItems && len(m.todoItems) == 0 && len(m.queueItems) > 0:
+		m.cursor = 0
+		m.stateMode(ModeQueue)
+	case mode == ModeQueue && len(m.queueItems) == 0:
+		m.cursor = 0
+		m.stateMode(ModeTodoItems)
+	}
+}
+
+// reorderSelection moves the selected to-do items to the top, or one step up
+// or down among their siblings, keeping the sub-items under their parents
+func (m *model) reorderSelection(direction string) {
+	selected := map[int]bool{}
+	for _, id := range m.selectionIds() {
+		selected[id] = true
+	}
+
+	children := map[int][]int{}
+	for _, t := range m.todoItems {
+		children[t.parentId] = append(children[t.parentId], t.id)
+	}
+
+	for parent, group := range children {
+		switch direction {
+		case "top":
+			slices.SortStableFunc(group, func(a, b int) int {
+				switch {
+				case selected[a] && !selected[b]:
+					return -1
+				case !selected[a] && selected[b]:
+					return 1
+				}
+				return 0
+			})
+		case "up":
+			for i := 1; i < len(group); i++ {
+				if selected[group[i]] && !selected[group[i-1]] {
+					group[i], group[i-1] = group[i-1], group[i]
+				}
+			}
+		case "down":
+			for i := len(group) - 2; i >= 0; i-- {
+				if selected[group[i]] && !selected[group[i+1]] {
+					group[i], group[i+1] = group[i+1], group[i]
+				}
+			}
+		}
+		children[parent] = group
+	}
+
+	order := make([]int, 0, len(m.todoItems))
+	var walk func(parent int)
+	walk = func(parent int) {
+		for _, id := range children[parent] {
+			order = append(order, id)
+			walk(id)
+		}
+	}
+	walk(0)
+
+	if err := m.db.ReorderTodos(m.proj.Id, order); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	// the range follows the cursor, so it's kept as marked items
+	for id := range selected {
+		m.marked[id] = true
+	}
+	m.rangeActive = false
+	m.reloadItems(m.todoItems[m.cursor].id)
+}
+
+// selectionView renders the number of the selected items in the footer
+func (m model) selectionView() string {
+	count := len(m.selectionIds())
+	s := fmt.Sprintf("%d selected", count)
+	if m.rangeActive && m.keys.markRange.Enabled() {
+		s += fmt.Sprintf(" (%s to mark the range)", m.keys.markRange.Help().Key)
+	}
+
+	var hints string
+	if m.markMode == ModeQueue {
+		hints = hintsView(
+			hint("delete", m.keys.delete),
+			hint("make todo", m.keys.move),
+			hint("branch", m.keys.branch),
+			hint("clear", m.keys.clear),
+		)
+	} else {
+		hints = hintsView(
+			hint("done", m.keys.toggleDone),
+			hint("delete", m.keys.delete),
+			hint("move", m.keys.move),
+			hint("branch", m.keys.branch),
+			hint("reorder", m.keys.pushTop, m.keys.pushUp, m.keys.pushDown),
+			hint("clear", m.keys.clear),
+		)
+	}
+	return styles.warning.Render(s) + styles.dimmed.Render(hints)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// opDropStash drops the stash with the hash after confirmation
+type opDropStash struct {
+	hash string
+	mode int
+}
+
+// stashBrowser lists the stashes of the repository, and shows
+// the diffstat of the stash under the cursor
+type stashBrowser struct {
+	picker  pickerModel
+	stashes []shell.StashEntry
+	stats   map[string]string
+	status  string
+}
+
+// openStashBrowser lists the stashes with the cursor on the stash with the
+// hash, or on the stash of the current item if the hash is not given
+func (m *model) openStashBrowser(hash, status string) {
+	stashes, err := shell.GetStashList()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	if len(stashes) == 0 {
+		m.stashBrowser = nil
+		m.errorMsg = "There are no stashes."
+		return
+	}
+
+	ids := []int{}
+	for _, s := range stashes {
+		if s.TodoId > 0 {
+			ids = append(ids, s.TodoId)
+		}
+	}
+
+	items := map[int]*base.ItemAndBranch{}
+	if len(ids) > 0 {
+		ibs, err := m.db.GetItemsAndBranch(ids)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return
+		}
+		for _, ib := range ibs {
+			items[ib.ItemId] = ib
+		}
+	}
+
+	options := make([]PickerOption, len(stashes))
+	for i, s := range stashes {
+		label, details := s.Message, []string{}
+		ib, ok := items[s.TodoId]
+		switch {
+		case ok:
+			label = fmt.Sprintf("#%d %s", s.TodoId, ib.ItemName)
+			if ib.BranchName != m.env.Branch {
+				details = append(details, ib.BranchName)
+			}
+		case s.TodoId > 0:
+			label = fmt.Sprintf("gitodo_%d", s.TodoId)
+			details = append(details, "item deleted")
+		default:
+			details = append(details, "not linked")
+		}
+		options[i] = PickerOption{Label: s.Ref + " " + label, Detail: strings.Join(append(details, s.Date), " • ")}
+	}
+
+	cursor := 0
+	if hash == "" {
+		if items := m.currentItems(); m.cursor < len(items) {
+			hash = stashHash(stashes, items[m.cursor].id)
+		}
+	}
+	if i := slices.IndexFunc(stashes, func(s shell.StashEntry) bool { return s.Hash == hash }); i >= 0 {
+		cursor = i
+	}
+
+	stats := map[string]string{}
+	if m.stashBrowser != nil {
+		stats = m.stashBrowser.stats
+	}
+
+	m.stashBrowser = &stashBrowser{
+		picker: pickerModel{
+			title:   "stashes of the repository:",
+			options: options,
+			cursor:  cursor,
+			height:  min(len(options), maxPickerRows) + 4,
+			width:   max(m.screenWidth-2, 0),
+			single:  true,
+			keys:    []pickerKey{{"a", "a apply"}, {"d", "d drop"}, {"l", "l link to the item"}},
+		},
+		stashes: stashes,
+		stats:   stats,
+		status:  status,
+	}
+
+	// scroll to the cursor
+	picker, _ := m.stashBrowser.picker.Update(nil)
+	m.stashBrowser.picker = picker.(pickerModel)
+	m.loadStashStat()
+}
+
+// stashHash returns the hash of the stash of the item, if there is one
+func stashHash(stashes []shell.StashEntry, todoId int) string {
+	for _, s := range stashes {
+		if s.TodoId == todoId {
+			return s.Hash
+		}
+	}
+	return ""
+}
+
+// loadStashStat reads the diffstat of the stash under the cursor,
+// unless it's been read already
+func (m *model) loadStashStat() {
+	b := m.stashBrowser
+	s := b.stashes[b.picker.cursor]
+	if _, ok := b.stats[s.Hash]; ok {
+		return
+	}
+
+	stat, err := shell.StashStat(s.Ref)
+	if err != nil {
+		stat = err.Error()
+	}
+	b.stats[s.Hash] = strings.TrimRight(stat, "\n")
+}
+
+// updateStashBrowser handles the keys of the stash browser, and applies,
+// drops or links the stash under the cursor to the current item
+func (m *model) updateStashBrowser(msg tea.KeyMsg) {
+	b := m.stashBrowser
+	result, _ := b.picker.Update(msg)
+	b.picker = result.(pickerModel)
+	m.errorMsg = ""
+
+	if !b.picker.done {
+		m.loadStashStat()
+		return
+	}
+
+	m.stashBrowser = nil
+	if !b.picker.confirmed {
+		return
+	}
+
+	s := b.stashes[b.picker.cursor]
+	m.stashBrowser = b
+
+	switch b.picker.chosen {
+	case "a":
+		if err := shell.ApplyStash(s.Ref); err != nil {
+			m.openStashBrowser(s.Hash, "")
+			m.errorMsg = err.Error()
+			return
+		}
+		m.openStashBrowser(s.Hash, "applied "+s.Ref+", the stash is kept")
+
+	case "d":
+		mode := m.mode
+		m.stashBrowser = nil
+		m.stateMode(ModeInput)
+		m.prompt = fmt.Sprintf("drop %s? (y/n) ", s.Ref)
+		m.pendingOp = opDropStash{hash: s.Hash, mode: mode}
+
+	case "l":
+		items := m.currentItems()
+		if m.cursor >= len(items) {
+			m.openStashBrowser(s.Hash, "")
+			return
+		}
+
+		item := items[m.cursor]
+		switch hash := stashHash(b.stashes, item.id); {
+		case hash == s.Hash:
+			m.openStashBrowser(s.Hash, "the stash is already linked to the item")
+		case hash != "":
+			m.openStashBrowser(s.Hash, "")
+			m.errorMsg = "The item already has a stash, drop or pop it first."
+		default:
+			err := shell.LinkStash(s.Ref, item.id)
+			m.refreshStash()
+			m.openStashBrowser(s.Hash, fmt.Sprintf("linked to #%d", item.id))
+			if err != nil {
+				m.errorMsg = err.Error()
+			}
+		}
+	}
+}
+
+// dropStash drops the stash confirmed in the prompt, and lists the rest
+func (m *model) dropStash(op opDropStash) {
+	m.stateMode(op.mode)
+
+	stashes, err := shell.GetStashList()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	i := slices.IndexFunc(stashes, func(s shell.StashEntry) bool { return s.Hash == op.hash })
+	if i < 0 {
+		m.errorMsg = "The stash doesn't exist anymore."
+		return
+	}
+
+	if err := shell.DropStash(stashes[i].Ref); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.refreshStash()
+	if len(stashes) > 1 {
+		m.openStashBrowser(stashes[max(i-1, 0)].Hash, "dropped "+stashes[i].Ref)
+	}
+}
+
+// refreshStash reads the stashes of the items again
+func (m *model) refreshStash() {
+	stash, err := shell.GetStashItems()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	for i, t := range m.todoItems {
+		m.todoItems[i].stash = stash[t.id]
+	}
+	for i, t := range m.queueItems {
+		m.queueItems[i].stash = stash[t.id]
+	}
+}
+
+// stashBrowserView renders the stashes with the diffstat of the one under
+// the cursor, followed by the result of the last action
+func (m model) stashBrowserView() string {
+	b := m.stashBrowser
+
+	lines := strings.Split(b.stats[b.stashes[b.picker.cursor].Hash], "\n")
+	if len(lines) > maxDetailsLines {
+		lines = slices.Concat(lines[:maxDetailsLines-2], []string{"…"}, lines[len(lines)-1:])
+	}
+
+	s := strings.Builder{}
+	s.WriteString("\n  " + strings.ReplaceAll(b.picker.View(), "\n", "\n  "))
+	s.WriteString("\n")
+	for _, line := range lines {
+		s.WriteString("\n  " + styles.dimmed.Render(line))
+	}
+
+	switch {
+	case m.errorMsg != "":
+		s.WriteString("\n\n  " + styles.danger.Render(m.errorMsg))
+	case b.status != "":
+		s.WriteString("\n\n  " + styles.success.Render(b.status))
+	}
+
+	return s.String()
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/drazengolic/gitodo/shell"
+	"github.com/muesli/termenv"
+)
+
+// theme holds the styles of the screens
+type theme struct {
+	bold         lipgloss.Style
+	dimmed       lipgloss.Style
+	success      lipgloss.Style
+	danger       lipgloss.Style
+	warning      lipgloss.Style
+	timer        lipgloss.Style
+	timerStopped lipgloss.Style
+	match        lipgloss.Style
+	checkMark    string
+	committed    string
+}
+
+var (
+	// the active theme
+	styles = defaultTheme()
+
+	themeOnce sync.Once
+	themeErr  error
+)
+
+// defaultTheme returns the colored theme
+func defaultTheme() theme {
+	green := lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#007700", Dark: "#00ff00"})
+	orange := lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#ff7500", Dark: "#ffa500"})
+
+	return theme{
+		bold:    lipgloss.NewStyle().Bold(true),
+		dimmed:  lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")),
+		success: green,
+		danger:  lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")),
+		warning: orange,
+		timer: lipgloss.NewStyle().
+			Background(lipgloss.Color("#ff0000")).
+			Foreground(lipgloss.Color("#ffffff")),
+		timerStopped: lipgloss.NewStyle().
+			Background(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#777777"}).
+			Foreground(lipgloss.AdaptiveColor{Light: "#ffffff", Dark: "#000000"}),
+		match:     orange.Underline(true),
+		checkMark: green.SetString("X").Bold(true).String(),
+		committed: green.SetString("committed").String(),
+	}
+}
+
+// monoTheme returns the theme without colors, that relies
+// on the text attributes only
+func monoTheme() theme {
+	bold := lipgloss.NewStyle().Bold(true)
+
+	return theme{
+		bold:         bold,
+		dimmed:       lipgloss.NewStyle().Faint(true),
+		success:      bold,
+		danger:       bold.Underline(true),
+		warning:      lipgloss.NewStyle().Italic(true),
+		timer:        lipgloss.NewStyle().Reverse(true),
+		timerStopped: lipgloss.NewStyle(),
+		match:        lipgloss.NewStyle().Underline(true),
+		checkMark:    bold.SetString("X").String(),
+		committed:    "committed",
+	}
+}
+
+// setupTheme applies the theme set with "git config gitodo.theme", which
+// is either "default" or "mono". The mono theme is always used when the
+// NO_COLOR environment variable is set. It returns an error if the theme
+// is unknown, in which case the default theme is used.
+func setupTheme() error {
+	themeOnce.Do(func() {
+		name, _ := shell.GitConfig("gitodo.theme")
+		noColor := os.Getenv("NO_COLOR") != ""
+		if noColor {
+			name = "mono"
+		}
+
+		switch name {
+		case "", "default":
+			styles = defaultTheme()
+		case "mono":
+			styles = monoTheme()
+			// NO_COLOR disables the text attributes as well,
+			// but the mono theme has no colors to disable
+			if noColor && lipgloss.ColorProfile() == termenv.Ascii {
+				lipgloss.SetColorProfile(termenv.ANSI)
+			}
+		default:
+			styles = defaultTheme()
+			themeErr = fmt.Errorf("Unknown theme %q, using the default one.", name)
+		}
+	})
+
+	return themeErr
+}
@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drazengolic/gitodo/base"
+)
+
+// opSwitchTimer stops the timer running for another branch and starts it
+// for this one. When asked on startup, declining it quits the program.
+type opSwitchTimer struct {
+	mode    int
+	startup bool
+}
+
+// startTicking starts the ticks of the timer unless they are running
+func (m *model) startTicking() tea.Cmd {
+	if m.ticking {
+		return nil
+	}
+	m.ticking = true
+	return doTick()
+}
+
+// toggleTimer starts or stops the timer for the branch
+func (m *model) toggleTimer() tea.Cmd {
+	if m.timerActive {
+		if _, _, err := m.db.StopTimer(); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.timerActive = false
+		m.refreshTime()
+		return nil
+	}
+
+	_, err := m.db.StartTimer(m.proj.Id)
+	var elsewhere *base.TimerRunningElsewhereError
+
+	switch {
+	case errors.As(err, &elsewhere):
+		m.promptSwitchTimer(elsewhere, false)
+		return nil
+	case err != nil:
+		m.errorMsg = err.Error()
+		return nil
+	}
+
+	m.timerActive = true
+	m.refreshTime()
+	return m.startTicking()
+}
+
+// switchTimer stops the timer running elsewhere and starts it here
+func (m *model) switchTimer() tea.Cmd {
+	if _, _, err := m.db.StopTimer(); err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+	return m.toggleTimer()
+}
+
+// promptSwitchTimer asks to move the timer running for another branch
+func (m *model) promptSwitchTimer(e *base.TimerRunningElsewhereError, startup bool) {
+	mode := m.mode
+	if mode == ModeInput {
+		mode = ModeTodoItems
+	}
+
+	m.stateMode(ModeInput)
+	m.prompt = fmt.Sprintf(
+		"timer running in %s [%s] for %s, stop it and start here? (y/n) ",
+		e.Proj.Folder, e.Proj.Branch, base.FormatSeconds(e.Entry.Duration()),
+	)
+	m.pendingOp = opSwitchTimer{mode: mode, startup: startup}
+}
+
+// refreshTime reads the tracked time and the sessions after
+// the timer was started or stopped
+func (m *model) refreshTime() {
+	timeTotal, err := m.db.GetProjectTime(m.proj.Id)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	m.timeTotal = timeTotal
+
+	if m.showSessions {
+		m.loadSessions()
+	}
+}
+
+// loadSessions reads today's timer sessions of the branch
+func (m *model) loadSessions() {
+	sessions, err := m.db.GetSessions(m.proj.Id, time.Now().Format(time.DateOnly))
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	m.sessions = sessions
+}
+
+// sessionsView renders the panel with today's timer sessions
+func (m model) sessionsView() string {
+	if !m.showSessions || !m.ready {
+		return ""
+	}
+
+	total := 0
+	lines := []string{}
+
+	for _, s := range m.sessions {
+		to, duration := s.To[11:16], s.DurationSec
+		if s.Running {
+			from, _ := time.ParseInLocation(time.DateTime, s.From, time.Local)
+			to, duration = "now", int(time.Since(from).Seconds())
+		}
+		total += duration
+
+		secs := base.FormatSeconds(duration)
+		if s.Running {
+			secs = styles.timer.Render(secs)
+		}
+		lines = append(lines, fmt.Sprintf("%s–%-5s  %s", s.From[11:16], to, secs))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, styles.dimmed.Render(fmt.Sprintf("no sessions, start the timer with '%s'", m.keys.timer.Help().Key)))
+	}
+
+	// the latest sessions are the most relevant
+	if len(lines) > maxDetailsLines-1 {
+		lines = append([]string{styles.dimmed.Render("…")}, lines[len(lines)-maxDetailsLines+2:]...)
+	}
+
+	b := strings.Builder{}
+	b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+	b.WriteRune('\n')
+	b.WriteString(styles.bold.Render(fmt.Sprintf("  TODAY'S SESSIONS (%s):", base.FormatSeconds(total))))
+	b.WriteRune('\n')
+	for _, line := range lines {
+		b.WriteString("  ")
+		b.WriteString(line)
+		b.WriteRune('\n')
+	}
+
+	return b.String()
+}
@@ -18,18 +18,21 @@ limitations under the License.
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 	"github.com/drazengolic/gitodo/base"
 	"github.com/drazengolic/gitodo/shell"
+	"github.com/drazengolic/gitodo/wordwrap"
 	"github.com/gen2brain/beeep"
 )
 
@@ -51,32 +54,6 @@ const (
 var (
 	// control channels
 	appChan chan appMsg
-
-	// various styles
-	greenText = lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#007700", Dark: "#00ff00"})
-
-	checkMark = greenText.SetString("X").Bold(true).String()
-
-	boldText = lipgloss.NewStyle().Bold(true)
-
-	committedBox = greenText.SetString("committed").String()
-
-	dimmedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#777777"))
-
-	redText = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
-
-	orangeText = lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "#ff7500", Dark: "#ffa500"})
-
-	timerStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#ff0000")).
-			Foreground(lipgloss.Color("#ffffff"))
-
-	timerStoppedStyle = lipgloss.NewStyle().
-				Background(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#777777"}).
-				Foreground(lipgloss.AdaptiveColor{Light: "#ffffff", Dark: "#000000"})
 )
 
 func init() {
@@ -92,6 +69,9 @@ type opPopStash int
 
 type TickMsg time.Time
 
+// maximum number of lines in the details pane
+const maxDetailsLines = 10
+
 // model is a structure that represents the state of the entire ui app
 type model struct {
 	todoItems    []todoItem
@@ -111,18 +91,38 @@ type model struct {
 	screenWidth  int
 	screenHeight int
 	showTodoId   bool
+	showDetails  bool
 	timeTotal    int
+	projEstimate int
 	timerActive  bool
+	ticking      bool
+	showSessions bool
+	sessions     []base.ReportTimeEntry
 	doneCount    int
+	collapsed    map[int]bool
+	filter       string
+	filtering    bool
+	filterInput  textinput.Model
+	matches      map[int][]int
+	shown        map[int]bool
+	editing      int
+	editId       int
+	editInput    textinput.Model
+	marked       map[int]bool
+	markMode     int
+	rangeActive  bool
+	rangeAnchor  int
+	branchPicker *pickerModel
+	branchNames  []string
+	stashBrowser *stashBrowser
+	keys         keyMap
+	mouse        mouseState
 }
 
 // initialModel creates the initial model from the data and the environment
 func initialModel(env *shell.DirEnv, db *base.TodoDb) model {
 	todoProjId := db.FetchProjectId(env.ProjDir, env.Branch)
 	queueProjId := db.FetchProjectId(env.ProjDir, "*")
-	todoItems := []todoItem{}
-	queueItems := []todoItem{}
-	doneCount := 0
 
 	stash, err := shell.GetStashItems()
 
@@ -133,34 +133,14 @@ func initialModel(env *shell.DirEnv, db *base.TodoDb) model {
 
 	proj := db.GetProject(todoProjId)
 
-	err = db.TodoItems(todoProjId, func(t base.Todo) {
-		todoItems = append(todoItems, todoItem{
-			id:        t.Id,
-			task:      t.Task,
-			done:      t.DoneAt.Valid,
-			committed: t.CommittedAt.Valid,
-			stash:     stash[t.Id],
-		})
-
-		if t.DoneAt.Valid {
-			doneCount++
-		}
-	})
+	todoItems, doneCount, err := loadItems(db, todoProjId, stash)
 
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
-	err = db.TodoItems(queueProjId, func(t base.Todo) {
-		queueItems = append(queueItems, todoItem{
-			id:        t.Id,
-			task:      t.Task,
-			done:      t.DoneAt.Valid,
-			committed: t.CommittedAt.Valid,
-			stash:     stash[t.Id],
-		})
-	})
+	queueItems, _, err := loadItems(db, queueProjId, stash)
 
 	if err != nil {
 		fmt.Println(err.Error())
@@ -174,6 +154,13 @@ func initialModel(env *shell.DirEnv, db *base.TodoDb) model {
 		os.Exit(1)
 	}
 
+	es, err := db.GetEstimateSummary(todoProjId)
+
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
 	cursor := 0
 	for i, t := range todoItems {
 		if !t.done {
@@ -183,22 +170,38 @@ func initialModel(env *shell.DirEnv, db *base.TodoDb) model {
 	}
 
 	model := model{
-		todoItems:   todoItems,
-		queueItems:  queueItems,
-		mode:        ModeTodoItems,
-		cursor:      cursor,
-		proj:        proj,
-		queueProjId: queueProjId,
-		env:         env,
-		db:          db,
-		showHelp:    false,
-		timeTotal:   timeTotal,
-		doneCount:   doneCount,
-	}
-
-	te := db.GetLatestTimeEntry()
-	if te != nil && te.ProjectId == proj.Id && te.Action == base.TimesheetActionStart {
+		todoItems:    todoItems,
+		queueItems:   queueItems,
+		mode:         ModeTodoItems,
+		cursor:       cursor,
+		proj:         proj,
+		queueProjId:  queueProjId,
+		env:          env,
+		db:           db,
+		showHelp:     false,
+		timeTotal:    timeTotal,
+		projEstimate: es.ProjectSec,
+		doneCount:    doneCount,
+		collapsed:    map[int]bool{},
+		matches:      map[int][]int{},
+		shown:        map[int]bool{},
+		marked:       map[int]bool{},
+		keys:         loadKeyMap(),
+	}
+
+	if err := setupTheme(); err != nil {
+		model.errorMsg = err.Error()
+	}
+
+	te, err := db.CheckTimer(proj.Id)
+	var elsewhere *base.TimerRunningElsewhereError
+
+	switch {
+	case errors.As(err, &elsewhere):
+		model.promptSwitchTimer(elsewhere, true)
+	case te != nil && te.Action == base.TimesheetActionStart:
 		model.timerActive = true
+		model.ticking = true
 	}
 
 	return model
@@ -217,140 +220,82 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds []tea.Cmd
 	)
 
+	// the cursors of the inputs blink via messages
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		if m.filtering {
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		if m.editing != editNone {
+			m.editInput, cmd = m.editInput.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	}
+
 	switch msg := msg.(type) {
 
 	case TickMsg:
+		if !m.timerActive {
+			m.ticking = false
+			return m, nil
+		}
 		m.timeTotal++
 		return m, doTick()
 
 	case tea.KeyMsg:
 
-		switch msg.String() {
-
-		// These keys should exit the program.
-		case "ctrl+c", "q", "Q":
-			go func() { appChan <- AppExit }()
+		if m.branchPicker != nil {
+			m.updateBranchPicker(msg)
+			m.updateHeight()
+			m.viewport.SetContent(m.Content())
 			return m, nil
+		}
 
-		// moving up
-		case "up", "k", "K":
-			if m.cursor > 0 {
-				m.cursor--
-			} else if m.cursor == 0 && m.mode == ModeQueue && len(m.todoItems) > 0 {
-				m.stateMode(ModeTodoItems)
-				m.cursor = len(m.todoItems) - 1
-			} else if m.cursor == 0 && m.mode == ModeTodoItems && len(m.queueItems) > 0 {
-				m.cursor = len(m.queueItems) - 1
-				m.stateMode(ModeQueue)
-				m.viewport.GotoBottom()
-			}
+		if m.stashBrowser != nil {
+			m.updateStashBrowser(msg)
+			m.updateHeight()
+			m.viewport.SetContent(m.Content())
+			return m, nil
+		}
 
-		// moving down
-		case "down", "j", "J":
-			if m.mode == ModeTodoItems && m.cursor < len(m.todoItems)-1 {
-				m.cursor++
-			} else if m.mode == ModeQueue && m.cursor < len(m.queueItems)-1 {
-				m.cursor++
-			} else if m.mode == ModeTodoItems && m.cursor == len(m.todoItems)-1 && len(m.queueItems) > 0 {
-				m.cursor = 0
-				m.stateMode(ModeQueue)
-			} else if m.mode == ModeQueue && m.cursor == len(m.queueItems)-1 && len(m.todoItems) > 0 {
-				m.cursor = 0
-				m.stateMode(ModeTodoItems)
-				m.viewport.GotoTop()
-			}
+		if m.editing != editNone {
+			cmd = m.updateEdit(msg)
+			m.updateHeight()
+			m.viewport.SetContent(m.Content())
+			return m, cmd
+		}
 
-		// toggle "done"
-		case "enter", " ":
-			if m.mode == ModeTodoItems {
-				done := !m.todoItems[m.cursor].done
-				err := m.db.TodoDone(m.todoItems[m.cursor].id, done)
-				if err != nil {
-					m.errorMsg = err.Error()
-				} else {
-					m.todoItems[m.cursor].done = done
-				}
+		if m.filtering {
+			cmd = m.updateFilter(msg)
+			m.updateHeight()
+			m.scrollToCursor()
+			return m, cmd
+		}
 
-				if done {
-					m.doneCount++
-				} else {
-					m.doneCount--
-				}
+		// when nothing matches the filter, there are no items to work on
+		if m.cursorHidden() {
+			if !key.Matches(msg, m.keys.quit, m.keys.filter, m.keys.clear, m.keys.help, m.keys.timer, m.keys.sessions) {
+				return m, nil
 			}
+		}
 
-		// move item to the top of the list
-		case "t", "T":
-			if m.mode == ModeTodoItems {
-				item := m.todoItems[m.cursor]
-				err := m.db.ChangePosition(item.id, m.cursor+1, 1)
-				if err != nil {
-					m.errorMsg = err.Error()
-				} else {
-					m.todoItems = slices.Insert(slices.Delete(m.todoItems, m.cursor, m.cursor+1), 0, item)
-					m.cursor = 0
-				}
-			}
-		// shift item to the one step above
-		case "ctrl+up", "ctrl+k":
-			if m.mode == ModeTodoItems && m.cursor > 0 {
-				item := m.todoItems[m.cursor]
-				err := m.db.ChangePosition(item.id, m.cursor+1, m.cursor)
-				if err != nil {
-					m.errorMsg = err.Error()
-				} else {
-					swapItem := m.todoItems[m.cursor-1]
-					m.todoItems[m.cursor-1] = item
-					m.todoItems[m.cursor] = swapItem
-					m.cursor--
-				}
-			}
-		// shift item to the one step below
-		case "ctrl+down", "ctrl+j":
-			if m.mode == ModeTodoItems && m.cursor < len(m.todoItems)-1 {
-				item := m.todoItems[m.cursor]
-				err := m.db.ChangePosition(item.id, m.cursor+1, m.cursor+2)
-				if err != nil {
-					m.errorMsg = err.Error()
-				} else {
-					swapItem := m.todoItems[m.cursor+1]
-					m.todoItems[m.cursor+1] = item
-					m.todoItems[m.cursor] = swapItem
-					m.cursor++
-				}
-			}
-		// delete item
-		case "d", "D":
-			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
-				if m.todoItems[m.cursor].done {
-					beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
-					break
-				}
-				m.stateMode(ModeInput)
-				m.prompt = "delete todo item: are you sure? (y/n) "
-				m.pendingOp = opDelTodoItem(m.cursor)
-			} else if m.mode == ModeQueue && len(m.queueItems) > 0 {
-				m.stateMode(ModeInput)
-				m.prompt = "delete queue item: are you sure? (y/n) "
-				m.pendingOp = opDelQueueItem(m.cursor)
-			}
+		switch {
 
 		// confirm prompt and do the pending op
-		case "y", "Y":
-			if m.mode != ModeInput {
-				break
-			}
-
+		case m.mode == ModeInput && key.Matches(msg, m.keys.confirm):
 			switch m.pendingOp.(type) {
 			case opDelTodoItem:
 				index := int(m.pendingOp.(opDelTodoItem))
 				item := m.todoItems[index]
 				err := m.db.Delete(item.id)
+				m.mode = ModeTodoItems
 				if err == nil {
-					m.todoItems = slices.Delete(m.todoItems, index, index+1)
+					// sub-items are moved up to the parent of the deleted item
+					delete(m.collapsed, item.id)
+					m.reloadItems(0)
 				} else {
 					m.errorMsg = err.Error()
 				}
-				m.mode = ModeTodoItems
 				if len(m.todoItems) == 0 {
 					m.cursor = 0
 					m.stateMode(ModeQueue)
@@ -359,12 +304,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				index := int(m.pendingOp.(opDelQueueItem))
 				item := m.queueItems[index]
 				err := m.db.Delete(item.id)
+				m.mode = ModeQueue
 				if err == nil {
-					m.queueItems = slices.Delete(m.queueItems, index, index+1)
+					// sub-items are moved up to the parent of the deleted item
+					delete(m.collapsed, item.id)
+					m.reloadItems(0)
 				} else {
 					m.errorMsg = err.Error()
 				}
-				m.mode = ModeQueue
 				if len(m.queueItems) == 0 {
 					m.cursor = 0
 					m.stateMode(ModeTodoItems)
@@ -385,6 +332,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.todoItems[index].stash = stashes[item.id]
 
+			case opDelItems, opMoveItems, opCopyItems:
+				m.applyBulkOp(m.pendingOp)
+
+			case opSwitchTimer:
+				m.stateMode(m.pendingOp.(opSwitchTimer).mode)
+				cmds = append(cmds, m.switchTimer())
+
+			case opDropStash:
+				m.dropStash(m.pendingOp.(opDropStash))
+
 			case opPopStash:
 				index := int(m.pendingOp.(opPopStash))
 				item := m.todoItems[index]
@@ -397,19 +354,208 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.todoItems[index].stash = shell.StashItem{}
 			}
 		// cancel prompt, clear pending op
-		case "n", "N":
-			if m.mode != ModeInput {
+		case m.mode == ModeInput && key.Matches(msg, m.keys.cancel):
+			switch op := m.pendingOp.(type) {
+			case opDelQueueItem:
+				m.stateMode(ModeQueue)
+			case opDelItems:
+				m.stateMode(op.mode)
+			case opMoveItems:
+				m.stateMode(op.mode)
+			case opCopyItems:
+				m.stateMode(op.mode)
+			case opDropStash:
+				m.stateMode(op.mode)
+				m.openStashBrowser(op.hash, "")
+			case opSwitchTimer:
+				if op.startup {
+					go func() { appChan <- AppExit }()
+					return m, nil
+				}
+				m.stateMode(op.mode)
+			default:
+				m.stateMode(ModeTodoItems)
+			}
+		// These keys should exit the program.
+		case key.Matches(msg, m.keys.quit):
+			go func() { appChan <- AppExit }()
+			return m, nil
+
+		// moving up
+		case key.Matches(msg, m.keys.up):
+			if m.mode == ModeTodoItems && m.prevVisible(m.cursor) >= 0 {
+				m.cursor = m.prevVisible(m.cursor)
+			} else if m.mode == ModeQueue && m.prevVisibleQueue(m.cursor) >= 0 {
+				m.cursor = m.prevVisibleQueue(m.cursor)
+			} else if m.rangeActive {
 				break
+			} else if m.mode == ModeQueue && m.prevVisible(len(m.todoItems)) >= 0 {
+				m.stateMode(ModeTodoItems)
+				m.cursor = m.prevVisible(len(m.todoItems))
+			} else if m.mode == ModeTodoItems && m.prevVisibleQueue(len(m.queueItems)) >= 0 {
+				m.cursor = m.prevVisibleQueue(len(m.queueItems))
+				m.stateMode(ModeQueue)
+				m.viewport.GotoBottom()
 			}
 
-			switch m.pendingOp.(type) {
-			case opDelQueueItem:
+		// moving down
+		case key.Matches(msg, m.keys.down):
+			if m.mode == ModeTodoItems && m.nextVisible(m.cursor) > 0 {
+				m.cursor = m.nextVisible(m.cursor)
+			} else if m.mode == ModeQueue && m.nextVisibleQueue(m.cursor) >= 0 {
+				m.cursor = m.nextVisibleQueue(m.cursor)
+			} else if m.rangeActive {
+				break
+			} else if m.mode == ModeTodoItems && m.nextVisibleQueue(-1) >= 0 {
+				m.cursor = m.nextVisibleQueue(-1)
 				m.stateMode(ModeQueue)
-			default:
+			} else if m.mode == ModeQueue && m.nextVisible(-1) >= 0 {
+				m.cursor = m.nextVisible(-1)
 				m.stateMode(ModeTodoItems)
+				m.viewport.GotoTop()
+			}
+
+		// toggle "done"
+		case key.Matches(msg, m.keys.toggleDone):
+			if m.mode == ModeTodoItems {
+				m.toggleDone()
+			}
+
+		// mark the item
+		case key.Matches(msg, m.keys.mark):
+			if m.mode != ModeInput {
+				m.toggleMark()
+			}
+		// select a range of items
+		case key.Matches(msg, m.keys.markRange):
+			if m.mode != ModeInput {
+				m.toggleRange()
+			}
+		// move item to the top of the list
+		// (sub-items stay with their parent, and move only among their siblings)
+		case key.Matches(msg, m.keys.pushTop):
+			if m.mode == ModeTodoItems && m.hasSelection() {
+				m.reorderSelection("top")
+			} else if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				item := m.todoItems[m.cursor]
+				err := m.db.ChangePosition(item.id, m.cursor+1, 1)
+				if err != nil {
+					m.errorMsg = err.Error()
+				} else {
+					m.reloadItems(item.id)
+				}
+			}
+		// shift item to the one step above
+		case key.Matches(msg, m.keys.pushUp):
+			if m.mode == ModeTodoItems && m.hasSelection() {
+				m.reorderSelection("up")
+			} else if m.mode == ModeTodoItems && m.cursor > 0 {
+				item := m.todoItems[m.cursor]
+				prev := m.prevSibling(m.cursor)
+				if prev < 0 {
+					break
+				}
+				err := m.db.ChangePosition(item.id, m.cursor+1, prev+1)
+				if err != nil {
+					m.errorMsg = err.Error()
+				} else {
+					m.reloadItems(item.id)
+				}
+			}
+		// shift item to the one step below
+		case key.Matches(msg, m.keys.pushDown):
+			if m.mode == ModeTodoItems && m.hasSelection() {
+				m.reorderSelection("down")
+			} else if m.mode == ModeTodoItems && m.cursor < len(m.todoItems)-1 {
+				item := m.todoItems[m.cursor]
+				next := m.nextSibling(m.cursor)
+				if next < 0 {
+					break
+				}
+				err := m.db.ChangePosition(item.id, m.cursor+1, m.subtreeEnd(next)+1)
+				if err != nil {
+					m.errorMsg = err.Error()
+				} else {
+					m.reloadItems(item.id)
+				}
+			}
+		// make item a sub-item of the item above it
+		case key.Matches(msg, m.keys.indent):
+			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				item := m.todoItems[m.cursor]
+				prev := m.prevSibling(m.cursor)
+				if prev < 0 {
+					beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
+					break
+				}
+				err := m.db.SetParent(item.id, m.todoItems[prev].id)
+				if err != nil {
+					m.errorMsg = err.Error()
+				} else {
+					delete(m.collapsed, m.todoItems[prev].id)
+					m.reloadItems(item.id)
+				}
+			}
+		// move sub-item one level up
+		case key.Matches(msg, m.keys.outdent):
+			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				item := m.todoItems[m.cursor]
+				parent := m.parentIndex(m.cursor)
+				if parent < 0 {
+					beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
+					break
+				}
+				grandparentId := 0
+				if gp := m.parentIndex(parent); gp >= 0 {
+					grandparentId = m.todoItems[gp].id
+				}
+				err := m.db.SetParent(item.id, grandparentId)
+				if err != nil {
+					m.errorMsg = err.Error()
+				} else {
+					m.reloadItems(item.id)
+				}
+			}
+		// collapse item, or go to the parent item
+		case key.Matches(msg, m.keys.collapse):
+			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				item := m.todoItems[m.cursor]
+				if m.hasChildren(m.cursor) && !m.collapsed[item.id] {
+					m.collapsed[item.id] = true
+				} else if parent := m.parentIndex(m.cursor); parent >= 0 {
+					m.cursor = parent
+				}
+			}
+		// expand item
+		case key.Matches(msg, m.keys.expand):
+			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				delete(m.collapsed, m.todoItems[m.cursor].id)
+			}
+		// delete item
+		case key.Matches(msg, m.keys.delete):
+			if m.mode != ModeInput && m.hasSelection() {
+				m.confirmDelete()
+			} else if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+				if m.todoItems[m.cursor].done {
+					beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
+					break
+				}
+				m.stateMode(ModeInput)
+				m.prompt = "delete todo item: are you sure? (y/n) "
+				m.pendingOp = opDelTodoItem(m.cursor)
+			} else if m.mode == ModeQueue && len(m.queueItems) > 0 {
+				m.stateMode(ModeInput)
+				m.prompt = "delete queue item: are you sure? (y/n) "
+				m.pendingOp = opDelQueueItem(m.cursor)
+			}
+
+		// edit the title of the item inline
+		case key.Matches(msg, m.keys.edit):
+			if m.mode != ModeInput {
+				cmds = append(cmds, m.startEdit())
 			}
 		// edit item in the external editor
-		case "e", "E":
+		case key.Matches(msg, m.keys.editExternal):
 			var coll []todoItem
 			if m.mode == ModeTodoItems {
 				coll = m.todoItems
@@ -422,7 +568,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			tmp, err := shell.NewTmpFileString(coll[m.cursor].task)
+			tmp, err := shell.NewTmpFileString(shell.AppendEstimate(
+				shell.AppendDue(coll[m.cursor].text(), coll[m.cursor].due), coll[m.cursor].estimate))
 			if err != nil {
 				m.errorMsg = err.Error()
 				break
@@ -436,18 +583,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.errorMsg = err.Error()
 				break
 			}
-			txt := strings.TrimSpace(tmp.ReadAll())
-			if txt != "" {
-				err = m.db.UpdateTask(coll[m.cursor].id, txt)
+			txt, due, _ := shell.ExtractDue(strings.TrimSpace(tmp.ReadAll()), time.Now())
+			txt, estimate, _ := shell.ExtractEstimate(txt)
+			if txt == "" {
+				break
+			}
+
+			err = m.db.UpdateItemText(coll[m.cursor].id, txt)
+			if err == nil {
+				err = m.db.SetDue(coll[m.cursor].id, due)
+			}
+			if err == nil {
+				err = m.db.SetEstimate(coll[m.cursor].id, estimate)
 			}
 
 			if err != nil {
 				m.errorMsg = err.Error()
 			} else {
-				coll[m.cursor].task = txt
+				coll[m.cursor].task, coll[m.cursor].notes = base.SplitTask(txt)
+				coll[m.cursor].due = due
+				coll[m.cursor].estimate = estimate
 			}
 
-		case "a", "A":
+		// add items inline
+		case key.Matches(msg, m.keys.add):
+			if m.mode != ModeInput {
+				cmds = append(cmds, m.startAdd())
+			}
+		// add items in the external editor
+		case key.Matches(msg, m.keys.addExternal):
 			tmp, err := shell.NewItemsTmpFile()
 			if err != nil {
 				m.errorMsg = err.Error()
@@ -473,17 +637,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
+			inputs := make([]base.TodoInput, len(items))
+			for i, item := range items {
+				inputs[i] = base.TodoInput{Task: item.Task, Level: item.Level, Due: item.Due, Estimate: item.Estimate}
+			}
+
 			if m.mode == ModeTodoItems {
-				err = m.db.AddTodos(m.proj.Id, items)
+				err = m.db.AddTodoTree(m.proj.Id, 0, inputs)
 			} else if m.mode == ModeQueue {
-				err = m.db.AddTodos(m.queueProjId, items)
+				err = m.db.AddTodoTree(m.queueProjId, 0, inputs)
 			} else {
 				break
 			}
 
 		// move to/from queue
-		case "m", "M":
-			if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
+		case key.Matches(msg, m.keys.move):
+			if m.mode == ModeTodoItems && m.hasSelection() {
+				m.confirmMove(m.queueProjId, "the queue")
+			} else if m.mode == ModeQueue && m.hasSelection() {
+				m.confirmMove(m.proj.Id, fmt.Sprintf("%q", m.env.Branch))
+			} else if m.mode == ModeTodoItems && len(m.todoItems) > 0 {
 				item := m.todoItems[m.cursor]
 				if item.done {
 					beeep.Beep(beeep.DefaultFreq, beeep.DefaultDuration)
@@ -492,12 +665,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				err := m.db.MoveTodo(item.id, m.queueProjId)
 
 				if err == nil {
-					m.queueItems = append(m.queueItems, item)
-					m.todoItems = slices.Delete(m.todoItems, m.cursor, m.cursor+1)
-					m.cursor--
-					if m.cursor < 0 {
-						m.cursor = 0
-					}
+					// sub-items are moved together with the item
+					m.cursor = m.prevVisible(m.cursor)
+					m.reloadItems(0)
 					if len(m.todoItems) == 0 {
 						m.stateMode(ModeQueue)
 					}
@@ -510,12 +680,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				err := m.db.MoveTodo(item.id, m.proj.Id)
 
 				if err == nil {
-					m.todoItems = append(m.todoItems, item)
-					m.queueItems = slices.Delete(m.queueItems, m.cursor, m.cursor+1)
 					m.cursor--
-					if m.cursor < 0 {
-						m.cursor = 0
-					}
+					m.reloadItems(0)
 					if len(m.queueItems) == 0 {
 						m.stateMode(ModeTodoItems)
 					}
@@ -524,11 +690,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		// toggle help display
-		case "?", "h", "H":
+		case key.Matches(msg, m.keys.help):
 			m.showHelp = !m.showHelp
 			m.updateHeight()
 		// save stash
-		case "s", "S":
+		case key.Matches(msg, m.keys.stash):
 			if m.mode == ModeTodoItems {
 				item := m.todoItems[m.cursor]
 				if item.stash.Date != "" {
@@ -540,18 +706,74 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.pendingOp = opPushStash(m.cursor)
 			}
 		// pop stash
-		case "p", "P":
+		case key.Matches(msg, m.keys.popStash):
 			item := m.todoItems[m.cursor]
 			if m.mode == ModeTodoItems && item.stash.Date != "" {
 				m.prompt = "pop changes from stash? (y/n) "
 				m.stateMode(ModeInput)
 				m.pendingOp = opPopStash(m.cursor)
 			}
+		// browse the stashes
+		case key.Matches(msg, m.keys.stashes):
+			if m.mode != ModeInput {
+				m.errorMsg = ""
+				m.openStashBrowser("", "")
+			}
+		// filter the items
+		case key.Matches(msg, m.keys.filter):
+			if m.mode != ModeInput {
+				cmds = append(cmds, m.startFilter())
+			}
+		// move to another branch
+		case key.Matches(msg, m.keys.branch):
+			if m.mode != ModeInput {
+				m.startBranchPicker()
+			}
+		// clear the selection, or the filter
+		case key.Matches(msg, m.keys.clear):
+			if m.mode != ModeInput && (m.rangeActive || len(m.marked) > 0) {
+				m.clearSelection()
+			} else if m.mode != ModeInput && m.filterActive() {
+				m.clearFilter()
+			}
+		// start or stop the timer
+		case key.Matches(msg, m.keys.timer):
+			if m.mode != ModeInput {
+				cmds = append(cmds, m.toggleTimer())
+			}
+		// toggle the panel with today's timer sessions
+		case key.Matches(msg, m.keys.sessions):
+			m.showSessions = !m.showSessions
+			if m.showSessions {
+				m.loadSessions()
+			}
 		// render todo item ids for advanced purposes
-		case "#":
+		case key.Matches(msg, m.keys.showId):
 			m.showTodoId = !m.showTodoId
+		// toggle the pane with the notes of the selected item
+		case key.Matches(msg, m.keys.notes):
+			m.showDetails = !m.showDetails
+		// jump to the next/previous item matching the filter
+		case key.Matches(msg, m.keys.nextMatch, m.keys.prevMatch):
+			if m.mode != ModeInput && m.filterActive() {
+				m.jumpToMatch(key.Matches(msg, m.keys.nextMatch), false)
+				m.scrollToCursor()
+			}
+		}
+
+		// the edited items might not match the filter anymore
+		if m.filterActive() {
+			m.updateMatches()
+			m.fixCursor()
 		}
 
+		// the height of the details depends on the selected item
+		m.updateHeight()
+
+	case tea.MouseMsg:
+		m.updateMouse(msg)
+		m.updateHeight()
+
 	case tea.WindowSizeMsg:
 		m.screenWidth = msg.Width
 		m.screenHeight = msg.Height
@@ -590,35 +812,74 @@ func (m model) View() string {
 }
 
 func (m model) Content() string {
+	content, _ := m.layout()
+	return content
+}
+
+// itemRow is the span of the lines of a rendered item within the content
+type itemRow struct {
+	mode, index int
+	start, end  int
+}
+
+// layout renders the content, and returns the rows of the shown items
+func (m model) layout() (string, []itemRow) {
 	if !m.ready {
-		return ""
+		return "", nil
 	}
 
 	builder := &strings.Builder{}
+	rows := []itemRow{}
+
+	// lineAt returns the number of lines written so far
+	counted, lines := 0, 0
+	lineAt := func() int {
+		s := builder.String()
+		lines += strings.Count(s[counted:], "\n")
+		counted = len(s)
+		return lines
+	}
 
 	// to-do items section
 
-	builder.WriteString(boldText.Render(fmt.Sprintf("  TO-DO LIST (%d/%d):", m.doneCount, len(m.todoItems))))
+	builder.WriteString(styles.bold.Render(fmt.Sprintf("  TO-DO LIST (%d/%d):", m.doneCount, len(m.todoItems))))
 	builder.WriteString("\n\n")
 
 	itemWidth := m.viewport.Width - 6
 
 	for i, choice := range m.todoItems {
-		selected := m.mode == ModeTodoItems && m.cursor == i
+		if m.isHidden(i) {
+			continue
+		}
+		row := itemRow{mode: ModeTodoItems, index: i, start: lineAt()}
+
+		selected := m.mode == ModeTodoItems && m.cursor == i && m.editing != editAdd
+		indent := strings.Repeat("  ", choice.level)
+
+		if m.collapsed[choice.id] && !m.filterActive() {
+			choice.folded = m.subtreeEnd(i) - i
+		}
 
 		cursor := " "
-		if selected {
-			cursor = boldText.Render(">")
+		switch {
+		case m.mouse.dragging && m.mouse.dragOver == i && i != m.mouse.dragFrom:
+			cursor = styles.warning.Bold(true).Render("↕")
+		case selected && m.isMarked(ModeTodoItems, i):
+			cursor = styles.warning.Bold(true).Render(">")
+		case selected:
+			cursor = styles.bold.Render(">")
+		case m.isMarked(ModeTodoItems, i):
+			cursor = styles.warning.Render("•")
 		}
 
 		var checked string
 		switch {
 		case selected && choice.done:
-			checked = boldText.Render("[") + checkMark + boldText.Render("]")
+			checked = styles.bold.Render("[") + styles.checkMark + styles.bold.Render("]")
 		case selected:
-			checked = boldText.Render("[ ]")
+			checked = styles.bold.Render("[ ]")
 		case choice.done:
-			checked = "[" + checkMark + "]"
+			checked = "[" + styles.checkMark + "]"
 		default:
 			checked = "[ ]"
 		}
@@ -626,39 +887,76 @@ func (m model) Content() string {
 		// Render the row
 		builder.WriteString(cursor)
 		builder.WriteRune(' ')
+		builder.WriteString(indent)
 		builder.WriteString(checked)
 		builder.WriteRune(' ')
-		builder.WriteString(choice.Render(selected, m.showTodoId, itemWidth, "      "))
+		if selected && m.editing == editItem {
+			builder.WriteString(m.editView(itemWidth - len(indent)))
+		} else {
+			builder.WriteString(choice.Render(selected, m.showTodoId, itemWidth-len(indent), "      "+indent, m.matches[choice.id]))
+		}
 		builder.WriteRune('\n')
 
+		row.end = lineAt()
+		rows = append(rows, row)
 	}
 
-	if len(m.queueItems) == 0 {
-		return builder.String()
+	if m.editing == editAdd && m.mode == ModeTodoItems {
+		builder.WriteString(styles.bold.Render(">") + " " + styles.bold.Render("[ ]") + " " + m.editView(itemWidth))
+		builder.WriteRune('\n')
+	}
+
+	if len(m.queueItems) == 0 || (m.filterActive() && m.nextVisibleQueue(-1) < 0) {
+		return builder.String(), rows
 	}
 
 	// queued items section
 	itemWidth = m.viewport.Width - 2
 
 	builder.WriteString("\n")
-	builder.WriteString(boldText.Render(fmt.Sprintf("  QUEUE (%d):", len(m.queueItems))))
+	builder.WriteString(styles.bold.Render(fmt.Sprintf("  QUEUE (%d):", len(m.queueItems))))
 	builder.WriteString("\n\n")
 
 	for i, choice := range m.queueItems {
-		selected := m.mode == ModeQueue && m.cursor == i
+		if m.isQueueHidden(i) {
+			continue
+		}
+		row := itemRow{mode: ModeQueue, index: i, start: lineAt()}
+
+		selected := m.mode == ModeQueue && m.cursor == i && m.editing != editAdd
 		cursor := " " // no cursor
-		if selected {
-			cursor = boldText.Render(">")
+		switch {
+		case selected && m.isMarked(ModeQueue, i):
+			cursor = styles.warning.Bold(true).Render(">")
+		case selected:
+			cursor = styles.bold.Render(">")
+		case m.isMarked(ModeQueue, i):
+			cursor = styles.warning.Render("•")
 		}
 
+		indent := strings.Repeat("  ", choice.level)
+
 		// Render the row
 		builder.WriteString(cursor)
 		builder.WriteRune(' ')
-		builder.WriteString(choice.Render(selected, false, itemWidth, "  "))
+		builder.WriteString(indent)
+		if selected && m.editing == editItem {
+			builder.WriteString(m.editView(itemWidth - len(indent)))
+		} else {
+			builder.WriteString(choice.Render(selected, false, itemWidth-len(indent), "  "+indent, m.matches[choice.id]))
+		}
+		builder.WriteRune('\n')
+
+		row.end = lineAt()
+		rows = append(rows, row)
+	}
+
+	if m.editing == editAdd && m.mode == ModeQueue {
+		builder.WriteString(styles.bold.Render(">") + " " + m.editView(itemWidth))
 		builder.WriteRune('\n')
 	}
 
-	return builder.String()
+	return builder.String(), rows
 }
 
 func (m model) headerView() string {
@@ -679,29 +977,63 @@ func (m model) headerView() string {
 	}
 
 	linew := m.viewport.Width - lipgloss.Width(m.env.Branch) - 2
-	b.WriteString(dimmedStyle.Render(strings.Repeat("─", linew/2)))
+	b.WriteString(styles.dimmed.Render(strings.Repeat("─", linew/2)))
 	b.WriteRune(' ')
 	b.WriteString(m.env.Branch)
 	b.WriteRune(' ')
-	b.WriteString(dimmedStyle.Render(strings.Repeat("─", linew/2+linew%2)))
+	b.WriteString(styles.dimmed.Render(strings.Repeat("─", linew/2+linew%2)))
 	b.WriteRune('\n')
 
-	if m.timeTotal > 0 || m.timerActive {
-		timew := m.viewport.Width - 8
+	estimate := m.estimateView()
+
+	if m.timeTotal > 0 || m.timerActive || estimate != "" {
 		secs := base.FormatSeconds(m.timeTotal)
-		b.WriteString(strings.Repeat(" ", timew/2))
 		if m.timerActive {
-			b.WriteString(timerStyle.Render(secs))
+			secs = styles.timer.Render(secs)
 		} else {
-			b.WriteString(timerStoppedStyle.Render(secs))
+			secs = styles.timerStopped.Render(secs)
 		}
 
-		b.WriteString(strings.Repeat(" ", timew/2+timew%2))
+		timew := m.viewport.Width - lipgloss.Width(secs+estimate)
+		b.WriteString(strings.Repeat(" ", max(timew/2, 0)))
+		b.WriteString(secs + estimate)
+		b.WriteString(strings.Repeat(" ", max(timew/2+timew%2, 0)))
 	}
 
 	return b.String()
 }
 
+// estimateView renders the remaining estimate next to the tracked time,
+// or an empty string if there are no estimates. The remaining estimate is
+// the sum of the estimates of the pending items, or the estimate of the
+// project minus the tracked time if no items are estimated.
+func (m model) estimateView() string {
+	itemsSec, remaining := 0, 0
+	for _, t := range m.todoItems {
+		itemsSec += t.estimate
+		if !t.done {
+			remaining += t.estimate
+		}
+	}
+
+	total := m.projEstimate
+	if total == 0 {
+		total = itemsSec
+	}
+	if total == 0 {
+		return ""
+	}
+	if itemsSec == 0 {
+		remaining = max(total-m.timeTotal, 0)
+	}
+
+	s := fmt.Sprintf(" • %s left of %s", base.FormatEstimate(remaining), base.FormatEstimate(total))
+	if m.timeTotal > total {
+		return styles.danger.Render(s)
+	}
+	return styles.dimmed.Render(s)
+}
+
 // footerView renders messages and a help table when enabled
 func (m model) footerView() string {
 	if !m.ready {
@@ -714,48 +1046,11 @@ func (m model) footerView() string {
 
 	b := strings.Builder{}
 
-	if m.showHelp && m.mode != ModeInput {
-		// help text per mode
-		var keyMap [][]string
-
-		if m.mode == ModeTodoItems {
-			keyMap = [][]string{
-				{"Up", "K"},
-				{"Down", "J"},
-				{"Toggle done", "⎵"},
-				{"Push up", "^K"},
-				{"Push down", "^J"},
-				{"Push to top", "T"},
-				{"Edit", "E"},
-				{"Delete", "D"},
-				{"Move to queue", "M"},
-				{"Stash", "S"},
-				{"Pop stash", "P"},
-				{"Add items", "A"},
-				{"Quit", "Q"},
-			}
-		} else {
-			keyMap = [][]string{
-				{"Up", "K"},
-				{"Down", "J"},
-				{"Make todo", "M"},
-				{"Edit", "E"},
-				{"Delete", "D"},
-				{"Add items", "A"},
-				{"Quit", "Q"},
-			}
-		}
-
-		keys := make([]string, 0, len(keyMap))
-		for _, k := range keyMap {
-			if len(k[1]) == 2 {
-				keys = append(keys, k[1]+" "+dimmedStyle.Render(k[0]))
-			} else {
-				keys = append(keys, " "+k[1]+" "+dimmedStyle.Render(k[0]))
-			}
-		}
+	b.WriteString(m.detailsView())
+	b.WriteString(m.sessionsView())
 
-		rows := slices.Collect(slices.Chunk(keys, 5))
+	if m.showHelp && m.mode != ModeInput {
+		rows := m.keys.helpRows(m.mode)
 
 		t := table.New().
 			Width(m.viewport.Width).
@@ -763,25 +1058,43 @@ func (m model) footerView() string {
 			Border(lipgloss.HiddenBorder()).
 			Rows(rows...)
 
-		b.WriteString(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
 		b.WriteString(t.String())
 		b.WriteRune('\n')
 	}
 
 	// render errors or prompts first if any
 	switch {
+	case m.branchPicker != nil:
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString("\n  " + strings.ReplaceAll(m.branchPicker.View(), "\n", "\n  "))
+	case m.stashBrowser != nil:
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(m.stashBrowserView())
 	case m.errorMsg != "":
-		b.WriteString(style.Render(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)) + "\n  " + redText.Render(m.errorMsg)))
+		b.WriteString(style.Render(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)) + "\n  " + styles.danger.Render(m.errorMsg)))
 	case m.mode == ModeInput:
-		b.WriteString(style.Render(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)) + "\n  " + orangeText.Render(m.prompt)))
+		b.WriteString(style.Render(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)) + "\n  " + styles.warning.Render(m.prompt)))
+	case m.editing == editItem:
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render(fmt.Sprintf("\n  ⏎ save • esc cancel • notes are edited with '%s'", m.keys.editExternal.Help().Key)))
+	case m.editing == editAdd:
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render("\n  ⏎ add the item • esc or ⏎ on empty text to finish"))
+	case m.hasSelection():
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString("\n  " + m.selectionView())
+	case m.filtering || m.filterActive():
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString("\n  " + m.filterView())
 	case m.mode == ModeTodoItems:
-		b.WriteString(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)))
-		b.WriteString(dimmedStyle.Render("\n  to-do items: toggle help with 'h' or '?'"))
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render(fmt.Sprintf("\n  to-do items: toggle help with '%s'", m.keys.help.Help().Key)))
 	case m.mode == ModeQueue:
-		b.WriteString(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)))
-		b.WriteString(dimmedStyle.Render("\n  queue items: toggle help with 'h' or '?'"))
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render(fmt.Sprintf("\n  queue items: toggle help with '%s'", m.keys.help.Help().Key)))
 	default:
-		b.WriteString(dimmedStyle.Render(strings.Repeat("─", m.viewport.Width)))
+		b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
 		b.WriteRune('\n')
 	}
 	return b.String()
@@ -803,14 +1116,76 @@ func (m model) getHeaderHeight() int {
 // getHeaderHeight calculates footer height from the state
 // because lipgloss.Height is not reliable
 func (m model) getFooterHeight() int {
+	h := 0
+	if details := m.detailsView(); details != "" {
+		h = strings.Count(details, "\n")
+	}
+	if sessions := m.sessionsView(); sessions != "" {
+		h += strings.Count(sessions, "\n")
+	}
+
+	// separator and a message, the branch picker or the stash browser
+	msg := 2
+	switch {
+	case m.branchPicker != nil:
+		msg = strings.Count(m.branchPicker.View(), "\n") + 2
+	case m.stashBrowser != nil:
+		msg = strings.Count(m.stashBrowserView(), "\n") + 1
+	}
+
+	if m.showHelp && m.mode != ModeInput {
+		h += len(m.keys.helpRows(m.mode)) + 2
+	}
+
+	return h + msg
+}
+
+// detailsView renders the title and the notes of the selected item
+func (m model) detailsView() string {
+	var coll []todoItem
 	switch {
-	case m.showHelp && m.mode == ModeTodoItems:
-		return 7
-	case m.showHelp && m.mode == ModeQueue:
-		return 6
+	case !m.showDetails || !m.ready || m.mode == ModeInput:
+		return ""
+	case m.mode == ModeQueue:
+		coll = m.queueItems
 	default:
-		return 2
+		coll = m.todoItems
+	}
+
+	if m.cursor >= len(coll) {
+		return ""
+	}
+
+	item := coll[m.cursor]
+	width := max(m.viewport.Width-4, 0)
+	lines := []string{styles.bold.Render(wordwrap.WrapText(item.task, width, "\n  "))}
+
+	if item.source != "" {
+		lines = append(lines, styles.dimmed.Render("from "+item.source))
+	}
+
+	if item.notes == "" {
+		lines = append(lines, styles.dimmed.Render(fmt.Sprintf("no notes, add them with '%s'", m.keys.editExternal.Help().Key)))
+	} else {
+		for _, line := range strings.Split(item.notes, "\n") {
+			lines = append(lines, strings.Split(wordwrap.WrapText(line, width, "\n"), "\n")...)
+		}
+	}
+
+	if len(lines) > maxDetailsLines {
+		lines = append(lines[:maxDetailsLines-1], styles.dimmed.Render("…"))
+	}
+
+	b := strings.Builder{}
+	b.WriteString(styles.dimmed.Render(strings.Repeat("─", m.viewport.Width)))
+	b.WriteRune('\n')
+	for _, line := range lines {
+		b.WriteString("  ")
+		b.WriteString(line)
+		b.WriteRune('\n')
 	}
+
+	return b.String()
 }
 
 // updateHeight updates the height of the viewport
@@ -851,7 +1226,10 @@ func RunTodoListUI(env *shell.DirEnv, db *base.TodoDb) {
 		for {
 			switch <-appChan {
 			case AppRefresh:
+				collapsed := model.collapsed
 				model = initialModel(env, db)
+				model.collapsed = collapsed
+				model.fixCursor()
 				p.Quit()
 			case AppReset:
 				p.Quit()
@@ -0,0 +1,209 @@
+/*
+Copyright © 2025 Dražen Golić
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"slices"
+
+	"github.com/drazengolic/gitodo/base"
+	"github.com/drazengolic/gitodo/shell"
+)
+
+// loadItems loads the items of the project along with their nesting levels
+// and returns them with the count of completed items
+func loadItems(db *base.TodoDb, projId int, stash map[int]shell.StashItem) ([]todoItem, int, error) {
+	todos := []base.Todo{}
+	err := db.TodoItems(projId, func(t base.Todo) {
+		todos = append(todos, t)
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	levels := base.ItemLevels(todos)
+	items := make([]todoItem, len(todos))
+	doneCount := 0
+
+	for i, t := range todos {
+		items[i] = todoItem{
+			id:        t.Id,
+			parentId:  int(t.ParentId.Int64),
+			level:     levels[t.Id],
+			task:      t.Task,
+			notes:     t.Notes,
+			due:       t.DueAt.String,
+			estimate:  int(t.Estimate.Int64),
+			source:    t.Source.String,
+			done:      t.DoneAt.Valid,
+			committed: t.CommittedAt.Valid,
+			stash:     stash[t.Id],
+		}
+
+		if t.DoneAt.Valid {
+			doneCount++
+		}
+	}
+
+	return items, doneCount, nil
+}
+
+// reloadItems reloads both lists after changes that affect more than one
+// item, and keeps the cursor on the item with the given id if it's found
+func (m *model) reloadItems(keepId int) {
+	stash := map[int]shell.StashItem{}
+	for _, t := range slices.Concat(m.todoItems, m.queueItems) {
+		if t.stash.Date != "" {
+			stash[t.id] = t.stash
+		}
+	}
+
+	todoItems, doneCount, err := loadItems(m.db, m.proj.Id, stash)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	queueItems, _, err := loadItems(m.db, m.queueProjId, stash)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.todoItems = todoItems
+	m.queueItems = queueItems
+	m.doneCount = doneCount
+	m.updateMatches()
+
+	coll := m.todoItems
+	if m.mode == ModeQueue {
+		coll = m.queueItems
+	}
+
+	if i := slices.IndexFunc(coll, func(t todoItem) bool { return t.id == keepId }); i >= 0 {
+		m.cursor = i
+	}
+
+	m.fixCursor()
+}
+
+// fixCursor keeps the cursor within the list and off the collapsed items
+func (m *model) fixCursor() {
+	count := len(m.todoItems)
+	if m.mode == ModeQueue {
+		count = len(m.queueItems)
+	}
+
+	m.cursor = max(min(m.cursor, count-1), 0)
+
+	if m.mode == ModeTodoItems {
+		for m.cursor > 0 && m.isHidden(m.cursor) {
+			m.cursor--
+		}
+	}
+
+	// the first items might not match the filter
+	if count > 0 && m.cursorHidden() {
+		m.jumpToMatch(true, true)
+	}
+}
+
+// hasChildren tells if the to-do item at the index has sub-items
+func (m model) hasChildren(i int) bool {
+	return i+1 < len(m.todoItems) && m.todoItems[i+1].level > m.todoItems[i].level
+}
+
+// subtreeEnd returns the index of the last sub-item of the to-do item
+// at the index, or the index itself if there are no sub-items
+func (m model) subtreeEnd(i int) int {
+	end := i
+	for end+1 < len(m.todoItems) && m.todoItems[end+1].level > m.todoItems[i].level {
+		end++
+	}
+	return end
+}
+
+// parentIndex returns the index of the parent of the to-do item
+// at the index, or -1 if it's a top level item
+func (m model) parentIndex(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if m.todoItems[j].level < m.todoItems[i].level {
+			return j
+		}
+	}
+	return -1
+}
+
+// prevSibling returns the index of the previous to-do item
+// with the same parent, or -1 if there is none
+func (m model) prevSibling(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		switch {
+		case m.todoItems[j].level == m.todoItems[i].level:
+			return j
+		case m.todoItems[j].level < m.todoItems[i].level:
+			return -1
+		}
+	}
+	return -1
+}
+
+// nextSibling returns the index of the next to-do item
+// with the same parent, or -1 if there is none
+func (m model) nextSibling(i int) int {
+	j := m.subtreeEnd(i) + 1
+	if j < len(m.todoItems) && m.todoItems[j].level == m.todoItems[i].level {
+		return j
+	}
+	return -1
+}
+
+// isHidden tells if the to-do item at the index is under a collapsed item,
+// or if it's filtered out when the items are filtered
+func (m model) isHidden(i int) bool {
+	if m.filterActive() {
+		return !m.shown[m.todoItems[i].id]
+	}
+	for p := m.parentIndex(i); p >= 0; p = m.parentIndex(p) {
+		if m.collapsed[m.todoItems[p].id] {
+			return true
+		}
+	}
+	return false
+}
+
+// prevVisible returns the index of the first visible to-do item
+// before the index, or -1 if there is none
+func (m model) prevVisible(i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if !m.isHidden(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+// nextVisible returns the index of the first visible to-do item
+// after the index, or -1 if there is none
+func (m model) nextVisible(i int) int {
+	for j := i + 1; j < len(m.todoItems); j++ {
+		if !m.isHidden(j) {
+			return j
+		}
+	}
+	return -1
+}